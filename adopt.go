@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/scp-fs2open/CommnodeWorker/config"
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
+	"github.com/scp-fs2open/CommnodeWorker/servers"
+)
+
+// adoptServers takes over the server containers which are still running from a previous run of the worker
+func (s *workerServer) adoptServers(ctx context.Context) error {
+	cfg := s.currentConfig()
+
+	serverContainers, err := containers.FindServerContainers(ctx, s.runtime, cfg.Containers)
+	if err != nil {
+		return err
+	}
+
+	for _, serverContainer := range serverContainers {
+		metadata := serverContainer.Metadata()
+		log.Printf("Adopting server %v from container %v", metadata.ServerId, serverContainer.ContainerId())
+
+		// All servers are registered right away so that their ports are reserved before any new server is started
+		server := s.serverManager.AdoptServer(metadata.ServerId, metadata.Name, metadata.Owner,
+			int32(metadata.PortOffset), metadata.Created)
+		server.AttachContainer(serverContainer)
+
+		go s.resumeServer(cfg, server, serverContainer)
+	}
+
+	return nil
+}
+
+// resumeServer checks that an adopted server is still working and then resumes managing it. Servers which do not
+// come back online are removed.
+func (s *workerServer) resumeServer(cfg *config.Config, server *servers.Server, serverContainer *containers.ServerContainer) {
+	events := newEventPublisher(server)
+
+	username, password := serverContainer.ApiCredentials()
+	fsoClient := fsoApi.NewClientWithDialer(serverContainer.ApiPort,
+		fsoApi.Credentials{Username: username, Password: password}, s.dialApi)
+
+	err := fsoClient.WaitForOnline(server.StartContext(), cfg.OnlineTimeout, nil)
+	if err != nil {
+		log.Printf("Adopted server %v did not come online (%v). Removing it...", server.Id, err)
+
+		var undo rollback
+		undo.add("server registration", func(ctx context.Context) error {
+			server.Release()
+			return nil
+		})
+		undo.add("server container", serverContainer.RemoveContainer)
+		undo.add("server API client", func(ctx context.Context) error {
+			fsoClient.Close()
+			return nil
+		})
+		undo.run()
+		return
+	}
+
+	events.publish(readyEvent(cfg, server, serverContainer, serverNamePrefix+server.Name))
+
+	server.ManageServer(serverContainer, fsoClient, func(event servers.LifecycleEvent) {
+		events.publish(convertLifecycleEvent(event))
+	})
+}
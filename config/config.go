@@ -0,0 +1,300 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	"github.com/scp-fs2open/CommnodeWorker/kube"
+	"github.com/scp-fs2open/CommnodeWorker/process"
+	"github.com/scp-fs2open/CommnodeWorker/servers"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	envPrefix = "COMMNODE_"
+
+	configFlag = "config"
+
+	containersFlagPrefix = "containers-"
+
+	// The containers section and its flags were called docker before there were other runtimes. The old names are
+	// still accepted but deprecated.
+	legacyContainersSection    = "docker"
+	legacyContainersFlagPrefix = "docker-"
+)
+
+// Supported runtimes for running the servers
+const (
+	RuntimeDocker     = "docker"
+	RuntimeProcess    = "process"
+	RuntimeKubernetes = "kubernetes"
+)
+
+// Config contains all settings of the worker
+type Config struct {
+	// Address on which the gRPC server listens
+	ListenAddress string `yaml:"listen_address"`
+
+	// The host name or address under which the game servers can be reached by players. Defaults to the host name.
+	PublicHost string `yaml:"public_host"`
+
+	// How long a new server may take until its API is reachable
+	OnlineTimeout time.Duration `yaml:"online_timeout"`
+
+	// Which runtime runs the servers. The containers settings apply to all runtimes.
+	Runtime string `yaml:"runtime"`
+
+	Containers containers.Config `yaml:"containers"`
+
+	// Only used by the process runtime
+	Process process.Config `yaml:"process"`
+
+	// Only used by the kubernetes runtime
+	Kubernetes kube.Config `yaml:"kubernetes"`
+
+	Servers servers.Config `yaml:"servers"`
+}
+
+// Default returns the settings used if nothing else is specified
+func Default() Config {
+	return Config{
+		ListenAddress: ":50051",
+		OnlineTimeout: time.Second * 5,
+		Runtime:       RuntimeDocker,
+		Containers:    containers.DefaultConfig(),
+		Process:       process.DefaultConfig(),
+		Kubernetes:    kube.DefaultConfig(),
+		Servers:       servers.DefaultConfig(),
+	}
+}
+
+// Validate checks if the settings are usable
+func (c *Config) Validate() error {
+	if c.ListenAddress == "" {
+		return errors.New("listen address must not be empty")
+	}
+	if c.OnlineTimeout <= 0 {
+		return errors.New("online timeout must be positive")
+	}
+	if c.Runtime != RuntimeDocker && c.Runtime != RuntimeProcess && c.Runtime != RuntimeKubernetes {
+		return fmt.Errorf("runtime must be %q, %q or %q", RuntimeDocker, RuntimeProcess, RuntimeKubernetes)
+	}
+	if err := c.Containers.Validate(); err != nil {
+		return fmt.Errorf("containers: %w", err)
+	}
+	if c.Runtime == RuntimeProcess {
+		if err := c.Process.Validate(); err != nil {
+			return fmt.Errorf("process: %w", err)
+		}
+		if !c.Servers.StopOnShutdown {
+			// Nothing could adopt the servers after a restart so they would run on without being managed
+			return errors.New("servers: stop on shutdown must be enabled for the process runtime")
+		}
+	}
+	if c.Runtime == RuntimeKubernetes {
+		if err := c.Kubernetes.Validate(); err != nil {
+			return fmt.Errorf("kubernetes: %w", err)
+		}
+	}
+	if err := c.Servers.Validate(); err != nil {
+		return fmt.Errorf("servers: %w", err)
+	}
+	if c.Containers.BaseApiPort+c.Servers.MaxServers > 65536 || c.Containers.BaseUdpPort+c.Servers.MaxServers > 65536 {
+		return errors.New("the port range of the servers exceeds the highest port")
+	}
+
+	return nil
+}
+
+// bindFlags registers a flag for every setting. The current values of the settings are used as defaults.
+func bindFlags(flags *flag.FlagSet, c *Config) {
+	flags.StringVar(&c.ListenAddress, "listen-address", c.ListenAddress, "address on which the gRPC server listens")
+	flags.StringVar(&c.PublicHost, "public-host", c.PublicHost, "host name or address under which players reach the game servers")
+	flags.DurationVar(&c.OnlineTimeout, "online-timeout", c.OnlineTimeout, "how long a new server may take until its API is reachable")
+
+	flags.StringVar(&c.Runtime, "runtime", c.Runtime, "runtime which runs the servers (docker, process or kubernetes)")
+
+	flags.StringVar(&c.Containers.InstanceId, "containers-instance-id", c.Containers.InstanceId, "identifies this worker if several workers share a Docker host or cluster")
+	flags.StringVar(&c.Containers.Image, "containers-image", c.Containers.Image, "the standalone server image")
+	flags.StringVar(&c.Containers.DataPath, "containers-data-path", c.Containers.DataPath, "directory on the host of the servers containing the game data")
+	flags.IntVar(&c.Containers.BaseApiPort, "containers-base-api-port", c.Containers.BaseApiPort, "first port used for the server APIs")
+	flags.IntVar(&c.Containers.BaseUdpPort, "containers-base-udp-port", c.Containers.BaseUdpPort, "first port used for the game servers")
+	flags.BoolVar(&c.Containers.ProbePorts, "containers-probe-ports", c.Containers.ProbePorts, "check that server ports are not used by other processes")
+	flags.DurationVar(&c.Containers.StopTimeout, "containers-stop-timeout", c.Containers.StopTimeout, "how long a server may take to exit before it is killed")
+	bindLegacyContainersFlags(flags)
+
+	flags.StringVar(&c.Process.BinaryPath, "process-binary-path", c.Process.BinaryPath, "the standalone server binary run by the process runtime")
+	flags.StringVar(&c.Process.WorkPath, "process-work-path", c.Process.WorkPath, "directory containing the working directories of the server processes")
+
+	flags.StringVar(&c.Kubernetes.Kubeconfig, "kubernetes-kubeconfig", c.Kubernetes.Kubeconfig, "kubeconfig file of the cluster running the servers instead of the cluster the worker runs in")
+	flags.StringVar(&c.Kubernetes.Namespace, "kubernetes-namespace", c.Kubernetes.Namespace, "namespace in which the server Pods are created")
+	flags.StringVar(&c.Kubernetes.NodeName, "kubernetes-node-name", c.Kubernetes.NodeName, "node on which the server Pods run")
+
+	flags.IntVar(&c.Servers.MaxServers, "servers-max-servers", c.Servers.MaxServers, "how many servers may run at the same time")
+	flags.DurationVar(&c.Servers.CheckInterval, "servers-check-interval", c.Servers.CheckInterval, "how often the players of a server are checked")
+	flags.DurationVar(&c.Servers.IdleTimeout, "servers-idle-timeout", c.Servers.IdleTimeout, "how long a server may be without players")
+	flags.DurationVar(&c.Servers.IdleWarning, "servers-idle-warning", c.Servers.IdleWarning, "how long before the idle shutdown a warning is sent")
+	flags.DurationVar(&c.Servers.PortQuarantine, "servers-port-quarantine", c.Servers.PortQuarantine, "how long the port of a stopped server stays unused if the removal of its container cannot be confirmed")
+	flags.BoolVar(&c.Servers.StopOnShutdown, "servers-stop-on-shutdown", c.Servers.StopOnShutdown, "stop all servers when the worker shuts down instead of adopting them on the next start")
+}
+
+// bindLegacyContainersFlags registers the deprecated docker- names of the containers flags as aliases
+func bindLegacyContainersFlags(flags *flag.FlagSet) {
+	var aliases []*flag.Flag
+	flags.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, containersFlagPrefix) {
+			aliases = append(aliases, f)
+		}
+	})
+
+	for _, f := range aliases {
+		name := legacyContainersFlagPrefix + strings.TrimPrefix(f.Name, containersFlagPrefix)
+		flags.Var(f.Value, name, "deprecated, use -"+f.Name)
+	}
+}
+
+// canonicalFlagName returns the current name of a flag which may be a deprecated alias
+func canonicalFlagName(name string) string {
+	if strings.HasPrefix(name, legacyContainersFlagPrefix) {
+		return containersFlagPrefix + strings.TrimPrefix(name, legacyContainersFlagPrefix)
+	}
+
+	return name
+}
+
+// envName returns the environment variable which corresponds to a flag
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+func newFlagSet(name string, configPath *string, c *Config) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(configPath, configFlag, os.Getenv(envName(configFlag)), "path of a YAML configuration file")
+	bindFlags(flags, c)
+
+	return flags
+}
+
+// configFile accepts the deprecated name of the containers section next to the settings
+type configFile struct {
+	Config `yaml:",inline"`
+
+	LegacyContainers *containers.Config `yaml:"docker"`
+}
+
+func loadFile(path string, c *Config) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	file := configFile{Config: *c}
+	if err := yaml.UnmarshalStrict(content, &file); err != nil {
+		return err
+	}
+	*c = file.Config
+
+	if file.LegacyContainers != nil {
+		log.Printf("The %v section of %v is deprecated. Rename it to containers.", legacyContainersSection, path)
+
+		// Applied on top of the containers section so that only the settings in the old section change
+		var legacy struct {
+			Containers *containers.Config `yaml:"docker"`
+		}
+		legacy.Containers = &c.Containers
+		if err := yaml.Unmarshal(content, &legacy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Load reads the configuration from the configuration file, environment variables and the command line arguments.
+// Later sources override earlier ones.
+func Load(name string, args []string) (*Config, error) {
+	c, err := load(name, args, Default())
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Runtime == RuntimeProcess && !c.Servers.StopOnShutdown {
+		// Servers of the process runtime cannot be adopted so they are stopped on shutdown unless a source says
+		// otherwise, which Validate rejects
+		defaults := Default()
+		defaults.Servers.StopOnShutdown = true
+		c, err = load(name, args, defaults)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return c, nil
+}
+
+// load applies all sources to the defaults
+func load(name string, args []string, c Config) (*Config, error) {
+
+	// The configuration file has to be loaded before the other sources so we need to find its path first
+	var configPath string
+	preFlags := newFlagSet(name, &configPath, &Config{})
+	preFlags.SetOutput(ioutil.Discard)
+	if err := preFlags.Parse(args); err != nil {
+		// Reported properly below
+		configPath = ""
+	}
+
+	if configPath != "" {
+		if err := loadFile(configPath, &c); err != nil {
+			return nil, fmt.Errorf("failed to load configuration file %v: %w", configPath, err)
+		}
+	}
+
+	flags := newFlagSet(name, &configPath, &c)
+
+	var envErr error
+	flags.VisitAll(func(f *flag.Flag) {
+		value, ok := os.LookupEnv(envName(f.Name))
+		if !ok || f.Name == configFlag || envErr != nil {
+			return
+		}
+		if canonical := canonicalFlagName(f.Name); canonical != f.Name {
+			if _, ok := os.LookupEnv(envName(canonical)); ok {
+				// The current name wins over the deprecated one
+				return
+			}
+		}
+
+		if err := f.Value.Set(value); err != nil {
+			envErr = fmt.Errorf("invalid value %q for %v: %w", value, envName(f.Name), err)
+		}
+	})
+	if envErr != nil {
+		return nil, envErr
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if c.PublicHost == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		c.PublicHost = host
+	}
+
+	return &c, nil
+}
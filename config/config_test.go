@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadStopOnShutdownDefault(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		stopOnShutdown bool
+		valid          bool
+	}{
+		{"docker", []string{"-runtime", "docker"}, false, true},
+		{"kubernetes", []string{"-runtime", "kubernetes", "-kubernetes-node-name", "node-1"}, false, true},
+		{"docker stopping", []string{"-runtime", "docker", "-servers-stop-on-shutdown"}, true, true},
+		{"process", []string{"-runtime", "process"}, true, true},
+		{"process leaving servers running", []string{"-runtime", "process", "-servers-stop-on-shutdown=false"}, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := Load("test", test.args)
+			if !test.valid {
+				if err == nil {
+					t.Error("expected the configuration to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load the configuration: %v", err)
+			}
+
+			if c.Servers.StopOnShutdown != test.stopOnShutdown {
+				t.Errorf("expected stop on shutdown to be %v, got %v", test.stopOnShutdown, c.Servers.StopOnShutdown)
+			}
+		})
+	}
+}
+
+func TestValidateProcessRuntimeStopsOnShutdown(t *testing.T) {
+	c := Default()
+	c.Runtime = RuntimeProcess
+	c.Process.BinaryPath = "/opt/fso/fs2_open"
+	c.Servers.StopOnShutdown = true
+
+	if err := c.Validate(); err != nil {
+		t.Fatalf("expected the configuration to be valid, got %v", err)
+	}
+
+	c.Servers.StopOnShutdown = false
+	if err := c.Validate(); err == nil {
+		t.Error("process servers cannot be adopted so they must be stopped on shutdown")
+	}
+}
+
+func TestLoadLegacyContainersNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("docker:\n  image: \"fso:old\"\n  data_path: \"/data/old\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("COMMNODE_DOCKER_INSTANCE_ID", "legacy")
+	t.Setenv("COMMNODE_DOCKER_DATA_PATH", "/data/legacy")
+	t.Setenv("COMMNODE_CONTAINERS_DATA_PATH", "/data/current")
+
+	c, err := Load("test", []string{"-config", path, "-docker-base-api-port", "9000", "-docker-probe-ports=false"})
+	if err != nil {
+		t.Fatalf("failed to load the configuration: %v", err)
+	}
+
+	defaults := Default()
+	if c.Containers.Image != "fso:old" || c.Containers.BaseUdpPort != defaults.Containers.BaseUdpPort {
+		t.Errorf("expected the docker section to change only its own settings, got %+v", c.Containers)
+	}
+	if c.Containers.InstanceId != "legacy" || c.Containers.DataPath != "/data/current" {
+		t.Errorf("expected the old environment variables to apply unless the new ones are set, got %+v", c.Containers)
+	}
+	if c.Containers.BaseApiPort != 9000 || c.Containers.ProbePorts {
+		t.Errorf("expected the old flags to apply, got %+v", c.Containers)
+	}
+}
+
+func TestLoadExample(t *testing.T) {
+	if _, err := Load("test", []string{"-config", "../config.example.yaml"}); err != nil {
+		t.Errorf("the example configuration does not load: %v", err)
+	}
+}
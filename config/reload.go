@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Settings which can only be changed by restarting the worker, identified by their path in the configuration file
+var restartOnly = map[string]bool{
+	// The gRPC server is already listening
+	"listen_address": true,
+	// Port offsets of running servers would map to different ports than their containers use
+	"containers.base_api_port": true,
+	"containers.base_udp_port": true,
+	// Containers created with the old ID would no longer be recognized as ours
+	"containers.instance_id": true,
+	// The runtime is created once on startup
+	"runtime":               true,
+	"process.binary_path":   true,
+	"process.work_path":     true,
+	"kubernetes.kubeconfig": true,
+	"kubernetes.namespace":  true,
+	"kubernetes.node_name":  true,
+}
+
+// Change describes a single setting which differs between two configurations
+type Change struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%v: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// fieldPath returns the path of a struct field in the configuration file
+func fieldPath(path string, field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	if path != "" {
+		name = path + "." + name
+	}
+
+	return name
+}
+
+func diffValues(path string, old reflect.Value, updated reflect.Value, changes []Change) []Change {
+	if old.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(old.Interface(), updated.Interface()) {
+			changes = append(changes, Change{Path: path, Old: old.Interface(), New: updated.Interface()})
+		}
+		return changes
+	}
+
+	for i := 0; i < old.NumField(); i++ {
+		changes = diffValues(fieldPath(path, old.Type().Field(i)), old.Field(i), updated.Field(i), changes)
+	}
+
+	return changes
+}
+
+// keepRestartOnly copies all settings which require a restart from the current values into the merged ones
+func keepRestartOnly(path string, current reflect.Value, merged reflect.Value) {
+	if restartOnly[path] {
+		merged.Set(current)
+		return
+	}
+	if current.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < current.NumField(); i++ {
+		keepRestartOnly(fieldPath(path, current.Type().Field(i)), current.Field(i), merged.Field(i))
+	}
+}
+
+// Diff lists all settings which differ between the two configurations
+func Diff(old *Config, updated *Config) []Change {
+	return diffValues("", reflect.ValueOf(*old), reflect.ValueOf(*updated), nil)
+}
+
+// Reload merges an updated configuration into the current one. Settings which can be changed at runtime are taken
+// from the updated configuration and returned as applied, changes to all other settings are returned as ignored.
+// Returns an error if the merged settings are not usable together, e.g. a new server limit with the old ports.
+func Reload(current *Config, updated *Config) (reloaded *Config, applied []Change, ignored []Change, err error) {
+	merged := *updated
+
+	keepRestartOnly("", reflect.ValueOf(*current), reflect.ValueOf(&merged).Elem())
+	if err := merged.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, change := range Diff(current, updated) {
+		if restartOnly[change.Path] {
+			ignored = append(ignored, change)
+		} else {
+			applied = append(applied, change)
+		}
+	}
+
+	return &merged, applied, ignored, nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReloadKeepsRestartOnlySettings(t *testing.T) {
+	current := Default()
+
+	updated := Default()
+	updated.ListenAddress = ":50052"
+	updated.Containers.BaseApiPort = 9000
+	updated.Containers.InstanceId = "other"
+	updated.Runtime = RuntimeProcess
+	updated.Process.BinaryPath = "/opt/fso/fs2_open"
+	updated.Process.WorkPath = "/tmp/commnode"
+	updated.Kubernetes.Namespace = "games"
+	updated.Kubernetes.NodeName = "node-1"
+	updated.OnlineTimeout = time.Minute * 3
+	updated.Servers.MaxServers = 4
+
+	reloaded, applied, ignored, err := Reload(&current, &updated)
+	if err != nil {
+		t.Fatalf("failed to reload: %v", err)
+	}
+
+	if reloaded.ListenAddress != current.ListenAddress ||
+		reloaded.Containers.BaseApiPort != current.Containers.BaseApiPort ||
+		reloaded.Containers.InstanceId != current.Containers.InstanceId ||
+		reloaded.Runtime != current.Runtime ||
+		reloaded.Process != current.Process ||
+		reloaded.Kubernetes != current.Kubernetes {
+		t.Errorf("restart-only settings were changed by the reload: %+v", reloaded)
+	}
+
+	if reloaded.OnlineTimeout != updated.OnlineTimeout || reloaded.Servers.MaxServers != updated.Servers.MaxServers {
+		t.Errorf("reloadable settings were not applied: %+v", reloaded)
+	}
+
+	if len(applied) != 2 {
+		t.Errorf("expected 2 applied changes, got %v", applied)
+	}
+	if len(ignored) != 8 {
+		t.Errorf("expected 8 ignored changes, got %v", ignored)
+	}
+}
+
+func TestReloadValidatesMergedSettings(t *testing.T) {
+	current := Default()
+	current.Containers.BaseApiPort = 65000
+
+	// Fine on its own but not with the base port which is kept until the restart
+	updated := Default()
+	updated.Servers.MaxServers = 1000
+
+	if _, _, _, err := Reload(&current, &updated); err == nil {
+		t.Error("expected the merged configuration to be rejected")
+	}
+}
@@ -0,0 +1,144 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+// Labels storing the server metadata on the container
+const (
+	labelServerId   = containerLabel + ".id"
+	labelName       = containerLabel + ".name"
+	labelOwner      = containerLabel + ".owner"
+	labelPortOffset = containerLabel + ".port_offset"
+	labelCreated    = containerLabel + ".created"
+	labelImage      = containerLabel + ".image"
+	labelInstance   = containerLabel + ".worker_instance"
+
+	containerNamePrefix = "fso-"
+)
+
+// ServerMetadata describes the server running in a container. It is stored in the labels of the container so that
+// the worker can restore its servers after a restart.
+type ServerMetadata struct {
+	ServerId   string
+	Name       string
+	Owner      string
+	PortOffset uint16
+	Created    time.Time
+}
+
+func (m ServerMetadata) labels(config Config) map[string]string {
+	return map[string]string{
+		containerLabel:  "",
+		labelServerId:   m.ServerId,
+		labelName:       m.Name,
+		labelOwner:      m.Owner,
+		labelPortOffset: strconv.FormatUint(uint64(m.PortOffset), 10),
+		labelCreated:    m.Created.UTC().Format(time.RFC3339),
+		labelImage:      config.Image,
+		labelInstance:   config.InstanceId,
+	}
+}
+
+// containerName returns the name of the container for a server so that operators can find it easily
+func (m ServerMetadata) containerName() string {
+	return containerNamePrefix + m.ServerId
+}
+
+func parseMetadata(labels map[string]string) (ServerMetadata, error) {
+	metadata := ServerMetadata{
+		ServerId: labels[labelServerId],
+		Name:     labels[labelName],
+		Owner:    labels[labelOwner],
+	}
+
+	if metadata.ServerId == "" {
+		return metadata, errors.New("container has no server ID")
+	}
+
+	portOffset, err := strconv.ParseUint(labels[labelPortOffset], 10, 16)
+	if err != nil {
+		return metadata, fmt.Errorf("invalid port offset: %w", err)
+	}
+	metadata.PortOffset = uint16(portOffset)
+
+	metadata.Created, err = time.Parse(time.RFC3339, labels[labelCreated])
+	if err != nil {
+		return metadata, fmt.Errorf("invalid creation time: %w", err)
+	}
+
+	return metadata, nil
+}
+
+func adoptContainer(runtime Runtime, config Config, info Info) (*ServerContainer, error) {
+	metadata, err := parseMetadata(info.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.ApiPort == 0 || info.UdpPort == 0 {
+		return nil, errors.New("container has no port bindings")
+	}
+	if info.ApiUsername == "" || info.ApiPassword == "" {
+		return nil, errors.New("container has no API credentials")
+	}
+
+	// The base ports might have changed since the container was created so use the actual bindings
+	return &ServerContainer{
+		ApiPort: info.ApiPort,
+		UdpPort: info.UdpPort,
+
+		runtime:     runtime,
+		config:      config,
+		imageName:   info.Image,
+		containerId: info.Id,
+		metadata:    metadata,
+
+		apiUsername: info.ApiUsername,
+		apiPassword: info.ApiPassword,
+	}, nil
+}
+
+// FindServerContainers returns the running server containers which were created by a previous run of the worker.
+// Containers which do not carry the metadata needed for managing them again are stopped.
+func FindServerContainers(ctx context.Context, runtime Runtime, config Config) ([]*ServerContainer, error) {
+	containerIds, err := runtime.List(ctx, containerLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	serverContainers := make([]*ServerContainer, 0, len(containerIds))
+	for _, containerId := range containerIds {
+		info, err := runtime.Inspect(ctx, containerId)
+		if errors.Is(err, ErrNotFound) {
+			// Exited in the meantime
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Containers created before instance IDs existed have no instance label and belong to us
+		if instance, ok := info.Labels[labelInstance]; ok && instance != config.InstanceId {
+			continue
+		}
+
+		serverContainer, err := adoptContainer(runtime, config, info)
+		if err == nil {
+			serverContainers = append(serverContainers, serverContainer)
+			continue
+		}
+
+		log.Printf("Cannot adopt container %v (%v). Stopping it...", containerId, err)
+		if err := runtime.Stop(ctx, containerId); err != nil {
+			return nil, err
+		}
+	}
+
+	return serverContainers, nil
+}
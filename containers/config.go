@@ -0,0 +1,69 @@
+package containers
+
+import (
+	"errors"
+	"time"
+)
+
+// Config contains the settings for running server containers
+type Config struct {
+	// Identifies this worker if several workers share a Docker host. Containers of other workers are left alone.
+	InstanceId string `yaml:"instance_id"`
+
+	// The standalone server image
+	Image string `yaml:"image"`
+
+	// Directory on the Docker host containing the FSO game data
+	DataPath string `yaml:"data_path"`
+
+	// The ports of a server are these base ports plus the port offset of the server
+	BaseApiPort int `yaml:"base_api_port"`
+	BaseUdpPort int `yaml:"base_udp_port"`
+
+	// Whether the host ports of a new server are checked for conflicts with other processes before they are used.
+	// Only works if the worker runs directly on the Docker host.
+	ProbePorts bool `yaml:"probe_ports"`
+
+	// How long the runtime waits for a server to exit before killing it
+	StopTimeout time.Duration `yaml:"stop_timeout"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		InstanceId:  "default",
+		Image:       "scpfs2open/fso-standalone:release",
+		DataPath:    "/data/fso/fs2",
+		BaseApiPort: 8080,
+		BaseUdpPort: 7808,
+		ProbePorts:  true,
+		StopTimeout: time.Second * 5,
+	}
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// Validate checks if the settings are usable
+func (c Config) Validate() error {
+	if c.InstanceId == "" {
+		return errors.New("instance ID must not be empty")
+	}
+	if c.Image == "" {
+		return errors.New("image must not be empty")
+	}
+	if c.DataPath == "" {
+		return errors.New("data path must not be empty")
+	}
+	if !validPort(c.BaseApiPort) {
+		return errors.New("base API port must be between 1 and 65535")
+	}
+	if !validPort(c.BaseUdpPort) {
+		return errors.New("base UDP port must be between 1 and 65535")
+	}
+	if c.StopTimeout < time.Second {
+		return errors.New("stop timeout must be at least one second")
+	}
+
+	return nil
+}
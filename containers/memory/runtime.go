@@ -0,0 +1,254 @@
+// Package memory implements a container runtime which only keeps track of its containers in memory. It does not run
+// anything and is meant for exercising the server management without a Docker daemon.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+)
+
+type container struct {
+	spec    containers.Spec
+	running bool
+	logs    bytes.Buffer
+
+	exitCode int64
+	exited   chan struct{}
+	removed  chan struct{}
+}
+
+// Runtime is an in-memory container runtime. Failures can be injected through the exported error fields.
+type Runtime struct {
+	// Returned by the respective operations if set
+	PullError   error
+	CreateError error
+	StartError  error
+
+	mutex      sync.Mutex
+	containers map[string]*container
+	nextId     int
+
+	// The exit codes of removed containers so that waiting for a container does not depend on whether it was
+	// removed already
+	exitCodes map[string]int64
+}
+
+func NewRuntime() *Runtime {
+	return &Runtime{
+		containers: make(map[string]*container),
+		exitCodes:  make(map[string]int64),
+	}
+}
+
+func (r *Runtime) find(id string) (*container, error) {
+	c, ok := r.containers[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", containers.ErrNotFound, id)
+	}
+
+	return c, nil
+}
+
+func (r *Runtime) Pull(ctx context.Context, image string, progressCb containers.PullProgressCallback) error {
+	if r.PullError != nil {
+		return r.PullError
+	}
+
+	return progressCb(containers.PullProgress{LayerId: image, Status: "Pull complete"})
+}
+
+func (r *Runtime) Create(ctx context.Context, spec containers.Spec) (string, error) {
+	if r.CreateError != nil {
+		return "", r.CreateError
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.nextId++
+	id := fmt.Sprintf("memory-%v", r.nextId)
+	r.containers[id] = &container{
+		spec:    spec,
+		exited:  make(chan struct{}),
+		removed: make(chan struct{}),
+	}
+
+	return id, nil
+}
+
+func (r *Runtime) Start(ctx context.Context, id string, progressCb containers.PullProgressCallback) error {
+	if r.StartError != nil {
+		return r.StartError
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, err := r.find(id)
+	if err != nil {
+		return err
+	}
+
+	c.running = true
+	return nil
+}
+
+func (r *Runtime) Wait(ctx context.Context, id string) (int64, error) {
+	r.mutex.Lock()
+	c, err := r.find(id)
+	exitCode, removed := r.exitCodes[id]
+	r.mutex.Unlock()
+
+	if removed {
+		return exitCode, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+
+	select {
+	case <-c.exited:
+		return c.exitCode, nil
+	case <-ctx.Done():
+		return -1, ctx.Err()
+	}
+}
+
+func (r *Runtime) Stop(ctx context.Context, id string) error {
+	return r.Exit(id, 0)
+}
+
+func (r *Runtime) Remove(ctx context.Context, id string) error {
+	return r.Exit(id, -1)
+}
+
+func (r *Runtime) WaitForRemoval(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	c, err := r.find(id)
+	r.mutex.Unlock()
+
+	if err != nil {
+		// Already gone
+		return nil
+	}
+
+	select {
+	case <-c.removed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Runtime) Logs(ctx context.Context, id string) (io.ReadCloser, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, err := r.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(c.logs.Bytes())), nil
+}
+
+func (r *Runtime) Stats(ctx context.Context, id string) (containers.Stats, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := r.find(id); err != nil {
+		return containers.Stats{}, err
+	}
+
+	return containers.Stats{}, nil
+}
+
+func (r *Runtime) Inspect(ctx context.Context, id string) (containers.Info, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, err := r.find(id)
+	if err != nil {
+		return containers.Info{}, err
+	}
+
+	return containers.Info{
+		Id:      id,
+		Image:   c.spec.Image,
+		Labels:  c.spec.Labels,
+		ApiPort: c.spec.ApiPort,
+		UdpPort: c.spec.UdpPort,
+
+		ApiUsername: c.spec.ApiUsername,
+		ApiPassword: c.spec.ApiPassword,
+	}, nil
+}
+
+func (r *Runtime) List(ctx context.Context, label string) ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var ids []string
+	for id, c := range r.containers {
+		if _, ok := c.spec.Labels[label]; ok && c.running {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// Exit simulates the server in the container exiting with the specified exit code
+func (r *Runtime) Exit(id string, exitCode int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, err := r.find(id)
+	if err != nil {
+		return err
+	}
+
+	c.running = false
+	c.exitCode = exitCode
+	close(c.exited)
+
+	// Like Docker does for auto removed containers
+	delete(r.containers, id)
+	r.exitCodes[id] = exitCode
+	close(c.removed)
+
+	return nil
+}
+
+// WriteLogs appends output of the server in the container
+func (r *Runtime) WriteLogs(id string, output string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, err := r.find(id)
+	if err != nil {
+		return err
+	}
+
+	c.logs.WriteString(output)
+	return nil
+}
+
+// Containers returns the IDs of all containers which have not been removed yet
+func (r *Runtime) Containers() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ids := make([]string, 0, len(r.containers))
+	for id := range r.containers {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
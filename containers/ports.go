@@ -0,0 +1,24 @@
+package containers
+
+import (
+	"fmt"
+	"net"
+)
+
+// PortsAvailable checks if the host ports a server with the specified port offset would use can be bound. This can
+// only detect conflicts if the Docker host is the host the worker runs on.
+func PortsAvailable(config Config, portOffset uint16) bool {
+	apiListener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%v", config.BaseApiPort+int(portOffset)))
+	if err != nil {
+		return false
+	}
+	_ = apiListener.Close()
+
+	udpConn, err := net.ListenPacket("udp", fmt.Sprintf(":%v", config.BaseUdpPort+int(portOffset)))
+	if err != nil {
+		return false
+	}
+	_ = udpConn.Close()
+
+	return true
+}
@@ -0,0 +1,109 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned by runtimes if a container does not exist (anymore)
+	ErrNotFound = errors.New("container not found")
+
+	// ErrPortConflict is returned by runtimes if a container cannot be started because its ports are in use
+	ErrPortConflict = errors.New("port already in use")
+
+	// ErrImageNotFound is returned by runtimes if the image or its tag does not exist
+	ErrImageNotFound = errors.New("image not found")
+
+	// ErrImagePull is returned by runtimes which pull the image only when the container starts if that pull fails
+	ErrImagePull = errors.New("image could not be pulled")
+)
+
+// Spec describes a server container which should be created
+type Spec struct {
+	Name   string
+	Image  string
+	Labels map[string]string
+	Args   []string
+
+	// Host ports of the server API and the game server
+	ApiPort uint16
+	UdpPort uint16
+
+	// The only credentials accepted by the server API. Runtimes pass them to the server in the configuration file
+	// created by ServerConfig.
+	ApiUsername string
+	ApiPassword string
+
+	// Directory containing the FSO game data
+	DataPath string
+
+	// How long the server may take to exit before it is killed
+	StopTimeout time.Duration
+}
+
+// Info describes an existing container
+type Info struct {
+	Id      string
+	Image   string
+	Labels  map[string]string
+	ApiPort uint16
+	UdpPort uint16
+
+	// Empty if the configuration of the server could not be read
+	ApiUsername string
+	ApiPassword string
+}
+
+// Stats contains the resource usage of a container
+type Stats struct {
+	// Total CPU time used by the container
+	CpuTime time.Duration
+
+	MemoryUsage uint64
+	MemoryLimit uint64
+}
+
+// PullProgressCallback is called for every progress report while pulling an image
+type PullProgressCallback = func(progress PullProgress) error
+
+// Runtime runs server containers. Containers are removed automatically by the runtime once they exit.
+type Runtime interface {
+	// Pull makes sure that the image is available. Returns an error wrapping ErrImageNotFound if it does not exist.
+	Pull(ctx context.Context, image string, progressCb PullProgressCallback) error
+
+	// Create creates a container without starting it and returns its ID
+	Create(ctx context.Context, spec Spec) (string, error)
+
+	// Start starts a created container and returns once it runs. Returns an error wrapping ErrPortConflict if its
+	// ports are in use. Runtimes which pull the image only now report the pull through progressCb and return an
+	// error wrapping ErrImagePull if it fails.
+	Start(ctx context.Context, id string, progressCb PullProgressCallback) error
+
+	// Wait blocks until the container is not running anymore and returns its exit code
+	Wait(ctx context.Context, id string) (int64, error)
+
+	// Stop asks the container to exit and kills it if it does not exit within its stop timeout
+	Stop(ctx context.Context, id string) error
+
+	// Remove forcibly removes the container even if it is still running. Returns an error wrapping ErrNotFound if
+	// the container does not exist.
+	Remove(ctx context.Context, id string) error
+
+	// WaitForRemoval blocks until the container has been removed
+	WaitForRemoval(ctx context.Context, id string) error
+
+	// Logs returns the combined stdout and stderr output of the container
+	Logs(ctx context.Context, id string) (io.ReadCloser, error)
+
+	// Stats returns the current resource usage of the container
+	Stats(ctx context.Context, id string) (Stats, error)
+
+	// Inspect returns the details of a container
+	Inspect(ctx context.Context, id string) (Info, error)
+
+	// List returns the IDs of all running containers which carry the specified label
+	List(ctx context.Context, label string) ([]string, error)
+}
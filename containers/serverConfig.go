@@ -0,0 +1,59 @@
+package containers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// The standalone server reads the settings of its web API from the multi.cfg in the data directory of its preferences
+// path. fs2_open finds that path through SDL which uses XDG_DATA_HOME on Linux. Setting the variable to a directory
+// owned by the server keeps the configuration of every server separate while all of them share the game data.
+const (
+	// PrefPathEnv selects the directory below which the server keeps its preferences
+	PrefPathEnv = "XDG_DATA_HOME"
+
+	// ServerConfigPath is the location of the multi.cfg relative to the directory in PrefPathEnv
+	ServerConfigPath = "HardLightProductions/FreeSpaceOpen/data/multi.cfg"
+)
+
+// Standalone options of the multi.cfg which configure the web API
+const (
+	optionApiPort     = "+webapiport"
+	optionApiUsername = "+webapiusername"
+	optionApiPassword = "+webapipassword"
+)
+
+// ServerConfig renders the multi.cfg which makes the server API listen on the API port of the spec and only accept the
+// credentials of the spec
+func ServerConfig(spec Spec) []byte {
+	var config bytes.Buffer
+
+	fmt.Fprintf(&config, "%v %v\n", optionApiPort, spec.ApiPort)
+	fmt.Fprintf(&config, "%v %v\n", optionApiUsername, spec.ApiUsername)
+	fmt.Fprintf(&config, "%v %v\n", optionApiPassword, spec.ApiPassword)
+
+	return config.Bytes()
+}
+
+// ParseServerConfig reads the API credentials from a multi.cfg created by ServerConfig. Runtimes use this for
+// restoring the credentials of containers they did not create themselves.
+func ParseServerConfig(config []byte) (username string, password string) {
+	scanner := bufio.NewScanner(bytes.NewReader(config))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case optionApiUsername:
+			username = fields[1]
+		case optionApiPassword:
+			password = fields[1]
+		}
+	}
+
+	return username, password
+}
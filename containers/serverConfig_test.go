@@ -0,0 +1,35 @@
+package containers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServerConfig(t *testing.T) {
+	spec := Spec{ApiPort: 8083, ApiUsername: "Kq3xV8mPzR2t", ApiPassword: "c7Hn4WbY9kLq2M5ZrT8xP3vJ6dF9gS2a"}
+
+	config := string(ServerConfig(spec))
+
+	for _, line := range []string{
+		"+webapiport 8083\n",
+		"+webapiusername Kq3xV8mPzR2t\n",
+		"+webapipassword c7Hn4WbY9kLq2M5ZrT8xP3vJ6dF9gS2a\n",
+	} {
+		if !strings.Contains(config, line) {
+			t.Errorf("expected %q in the configuration:\n%v", line, config)
+		}
+	}
+
+	username, password := ParseServerConfig([]byte(config))
+	if username != spec.ApiUsername || password != spec.ApiPassword {
+		t.Errorf("expected the credentials to round trip, got %q and %q", username, password)
+	}
+}
+
+func TestParseServerConfigIgnoresOtherOptions(t *testing.T) {
+	username, password := ParseServerConfig([]byte("+name My Server\n+webapiusername admin\n\n+webapipassword\n"))
+
+	if username != "admin" || password != "" {
+		t.Errorf("unexpected credentials %q and %q", username, password)
+	}
+}
@@ -0,0 +1,251 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type ServerContainer struct {
+	ApiPort uint16
+	UdpPort uint16
+
+	runtime   Runtime
+	config    Config
+	imageName string
+
+	// Only written by Start but the server may already be listed while it is starting
+	idMutex     sync.Mutex
+	containerId string
+
+	metadata ServerMetadata
+
+	apiUsername string
+	apiPassword string
+}
+
+// NewServerContainer prepares a new server container. The server API of the container will only accept the
+// specified credentials.
+func NewServerContainer(runtime Runtime, config Config, metadata ServerMetadata, apiUsername string, apiPassword string) *ServerContainer {
+	return &ServerContainer{
+		ApiPort: uint16(config.BaseApiPort) + metadata.PortOffset,
+		UdpPort: uint16(config.BaseUdpPort) + metadata.PortOffset,
+
+		runtime:   runtime,
+		config:    config,
+		imageName: config.Image,
+		metadata:  metadata,
+
+		apiUsername: apiUsername,
+		apiPassword: apiPassword,
+	}
+}
+
+const (
+	ProgressPulling  = iota
+	ProgressStarting = iota
+	ProgressStarted  = iota
+)
+
+const (
+	containerLabel = "fso_server"
+
+	// How often starting a container is attempted if its ports are still allocated and how long we wait initially
+	portConflictRetries = 4
+	portConflictDelay   = time.Second
+)
+
+const (
+	// Minimum time between two progress reports of the same pull status of a layer
+	pullProgressInterval = time.Second
+)
+
+// PullProgress describes the state of a single layer during an image pull
+type PullProgress struct {
+	LayerId string
+	Status  string
+	Current int64
+	Total   int64
+}
+
+// ContainerEvent contains the details of a progress report while starting a container
+type ContainerEvent struct {
+	State   uint32
+	Message string
+
+	// Only set for ProgressPulling events which report on a specific layer
+	Pull *PullProgress
+
+	// Only set for ProgressStarted events
+	ContainerId string
+}
+
+type ContainerProgress = func(event ContainerEvent) error
+
+type layerProgress struct {
+	status   string
+	lastSent time.Time
+}
+
+// throttlePullProgress only forwards progress reports when the state of a layer changes or enough time has passed to
+// avoid flooding the client with messages
+func throttlePullProgress(progressCb ContainerProgress) PullProgressCallback {
+	layers := make(map[string]*layerProgress)
+
+	return func(progress PullProgress) error {
+		now := time.Now()
+		layer, ok := layers[progress.LayerId]
+		if !ok {
+			layer = &layerProgress{}
+			layers[progress.LayerId] = layer
+		} else if layer.status == progress.Status && now.Sub(layer.lastSent) < pullProgressInterval {
+			return nil
+		}
+		layer.status = progress.Status
+		layer.lastSent = now
+
+		return progressCb(ContainerEvent{State: ProgressPulling, Message: progress.Status, Pull: &progress})
+	}
+}
+
+func (s *ServerContainer) Start(ctx context.Context, progressCb ContainerProgress) error {
+	if err := progressCb(ContainerEvent{State: ProgressPulling, Message: s.imageName}); err != nil {
+		return err
+	}
+
+	if err := s.runtime.Pull(ctx, s.imageName, throttlePullProgress(progressCb)); err != nil {
+		return err
+	}
+
+	if err := progressCb(ContainerEvent{State: ProgressStarting, Message: s.imageName}); err != nil {
+		return err
+	}
+
+	containerId, err := s.runtime.Create(ctx, Spec{
+		Name:   s.metadata.containerName(),
+		Image:  s.imageName,
+		Labels: s.metadata.labels(s.config),
+		Args: []string{
+			"-port",
+			strconv.FormatUint(uint64(s.UdpPort), 10),
+		},
+		ApiPort:     s.ApiPort,
+		UdpPort:     s.UdpPort,
+		ApiUsername: s.apiUsername,
+		ApiPassword: s.apiPassword,
+		DataPath:    s.config.DataPath,
+		StopTimeout: s.config.StopTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Remember the container right away so that it can be removed if something below fails
+	s.idMutex.Lock()
+	s.containerId = containerId
+	s.idMutex.Unlock()
+
+	if err := s.startContainer(ctx, throttlePullProgress(progressCb)); err != nil {
+		return err
+	}
+
+	return progressCb(ContainerEvent{State: ProgressStarted, Message: s.imageName, ContainerId: containerId})
+}
+
+func (s *ServerContainer) WaitForNotRunning(ctx context.Context) <-chan int64 {
+	// Buffered so that the goroutine can finish even if nobody receives the exit code anymore
+	signalChan := make(chan int64, 1)
+	go func() {
+		exitCode, err := s.runtime.Wait(ctx, s.containerId)
+		if err != nil {
+			log.Printf("Error while waiting for container exit: %v", err)
+			exitCode = -1
+		}
+
+		signalChan <- exitCode
+		close(signalChan)
+	}()
+
+	return signalChan
+}
+
+// startContainer starts the created container. The ports of a container which was just removed may still be held
+// by the runtime for a moment so port conflicts are retried a few times.
+func (s *ServerContainer) startContainer(ctx context.Context, progressCb PullProgressCallback) error {
+	delay := portConflictDelay
+	for attempt := 1; ; attempt++ {
+		err := s.runtime.Start(ctx, s.containerId, progressCb)
+		if err == nil || !errors.Is(err, ErrPortConflict) || attempt >= portConflictRetries {
+			return err
+		}
+
+		log.Printf("Ports of container %v are still in use (%v). Retrying in %v...", s.containerId, err, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// Metadata returns the metadata of the server running in this container
+func (s *ServerContainer) Metadata() ServerMetadata {
+	return s.metadata
+}
+
+// ApiCredentials returns the credentials accepted by the server API of the container
+func (s *ServerContainer) ApiCredentials() (username string, password string) {
+	return s.apiUsername, s.apiPassword
+}
+
+// ContainerId returns the ID of the started container or an empty string if the container was not started yet
+func (s *ServerContainer) ContainerId() string {
+	s.idMutex.Lock()
+	defer s.idMutex.Unlock()
+
+	return s.containerId
+}
+
+func (s *ServerContainer) StopContainer(ctx context.Context) error {
+	return s.runtime.Stop(ctx, s.containerId)
+}
+
+// RemoveContainer forcibly removes the container even if it is still running. Does nothing if no container was
+// created yet.
+func (s *ServerContainer) RemoveContainer(ctx context.Context) error {
+	if s.containerId == "" {
+		return nil
+	}
+
+	err := s.runtime.Remove(ctx, s.containerId)
+	if errors.Is(err, ErrNotFound) {
+		// Auto removal was faster than us
+		return nil
+	}
+
+	return err
+}
+
+// WaitForRemoval blocks until the runtime has removed the container
+func (s *ServerContainer) WaitForRemoval(ctx context.Context) error {
+	if s.containerId == "" {
+		return nil
+	}
+
+	return s.runtime.WaitForRemoval(ctx, s.containerId)
+}
+
+// Logs returns the output of the server
+func (s *ServerContainer) Logs(ctx context.Context) (io.ReadCloser, error) {
+	return s.runtime.Logs(ctx, s.containerId)
+}
+
+// Stats returns the current resource usage of the server
+func (s *ServerContainer) Stats(ctx context.Context) (Stats, error) {
+	return s.runtime.Stats(ctx, s.containerId)
+}
@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"context"
+	"github.com/docker/cli/cli/connhelper"
+	"github.com/docker/cli/cli/connhelper/commandconn"
+	"github.com/docker/cli/cli/connhelper/ssh"
+	"github.com/docker/docker/client"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+func GetDockerOptions() ([]client.Opt, error) {
+	host := os.Getenv("DOCKER_HOST")
+
+	var clientOpts []client.Opt
+
+	if strings.HasPrefix(host, "ssh://") {
+		helper, err := connhelper.GetConnectionHelper(host)
+
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient := &http.Client{
+			// No tls
+			// No proxy
+			Transport: &http.Transport{
+				DialContext: helper.Dialer,
+			},
+		}
+
+		clientOpts = append(clientOpts,
+			client.WithHTTPClient(httpClient),
+			client.WithHost(helper.Host),
+			client.WithDialContext(helper.Dialer),
+		)
+	} else if len(host) > 0 {
+		clientOpts = append(clientOpts,
+			client.WithHost(host))
+	}
+
+	version := os.Getenv("DOCKER_API_VERSION")
+
+	if version != "" {
+		clientOpts = append(clientOpts, client.WithVersion(version))
+	} else {
+		clientOpts = append(clientOpts, client.WithAPIVersionNegotiation())
+	}
+
+	return clientOpts, nil
+}
+
+// DialFunc opens a connection to an address on the Docker host
+type DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// isLocalHost checks if a Docker host refers to the machine the worker runs on
+func isLocalHost(host string) bool {
+	if host == "" || strings.HasPrefix(host, "unix://") || strings.HasPrefix(host, "npipe://") {
+		return true
+	}
+
+	hostUrl, err := url.Parse(host)
+	if err != nil {
+		return false
+	}
+
+	hostname := hostUrl.Hostname()
+	if hostname == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(hostname)
+	return ip != nil && ip.IsLoopback()
+}
+
+// GetApiDialer returns a function for reaching ports bound to the loopback interface of a remote Docker host. The
+// connections are forwarded by ssh the same way the Docker API is reached. Returns nil if the Docker host is local or
+// cannot be reached through ssh.
+func GetApiDialer() (DialFunc, error) {
+	host := os.Getenv("DOCKER_HOST")
+
+	if !strings.HasPrefix(host, "ssh://") {
+		if !isLocalHost(host) {
+			log.Printf("WARNING: Docker host %v is not local. The server APIs are only bound to its loopback interface "+
+				"and will not be reachable. Use an ssh:// DOCKER_HOST instead.", host)
+		}
+		return nil, nil
+	}
+
+	spec, err := ssh.ParseURL(host)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		// The address is resolved on the Docker host so 127.0.0.1 refers to the Docker host
+		return commandconn.New(ctx, "ssh", append([]string{"-W", addr}, spec.Args()...)...)
+	}, nil
+}
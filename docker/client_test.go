@@ -0,0 +1,25 @@
+package docker
+
+import "testing"
+
+func TestIsLocalHost(t *testing.T) {
+	tests := []struct {
+		host  string
+		local bool
+	}{
+		{"", true},
+		{"unix:///var/run/docker.sock", true},
+		{"npipe:////./pipe/docker_engine", true},
+		{"tcp://127.0.0.1:2375", true},
+		{"tcp://localhost:2375", true},
+		{"tcp://[::1]:2375", true},
+		{"tcp://192.168.1.10:2376", false},
+		{"tcp://docker.example.com:2376", false},
+	}
+
+	for _, test := range tests {
+		if local := isLocalHost(test.host); local != test.local {
+			t.Errorf("isLocalHost(%q) = %v, expected %v", test.host, local, test.local)
+		}
+	}
+}
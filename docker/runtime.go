@@ -0,0 +1,365 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+)
+
+const (
+	// The standalone image expects the game data here
+	dataMountPath = "/fso"
+
+	// The server keeps its preferences including its configuration below this directory. It is created when the
+	// configuration is copied into the container.
+	prefPath = "/commnode"
+
+	// A multi.cfg is only a few lines so anything larger is not ours
+	maxServerConfigSize = 4096
+)
+
+// Runtime runs the server containers on a Docker host
+type Runtime struct {
+	dockerClient client.APIClient
+}
+
+func NewRuntime(dockerClient client.APIClient) *Runtime {
+	return &Runtime{dockerClient: dockerClient}
+}
+
+// convertError maps Docker errors to the errors of the containers package
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if client.IsErrNotFound(err) {
+		return fmt.Errorf("%w: %v", containers.ErrNotFound, err)
+	}
+
+	message := err.Error()
+	if strings.Contains(message, "port is already allocated") || strings.Contains(message, "address already in use") {
+		return fmt.Errorf("%w: %v", containers.ErrPortConflict, err)
+	}
+
+	return err
+}
+
+func readPullProgress(readCloser io.ReadCloser, progressCb containers.PullProgressCallback) (err error) {
+	defer func() {
+		closeErr := readCloser.Close()
+
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	decoder := json.NewDecoder(readCloser)
+
+	for {
+		var message jsonmessage.JSONMessage
+		if err = decoder.Decode(&message); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if message.Error != nil {
+			return message.Error
+		}
+
+		log.Printf("Docker: %v %v %v", message.ID, message.Status, message.ProgressMessage)
+
+		progress := containers.PullProgress{
+			LayerId: message.ID,
+			Status:  message.Status,
+		}
+		if message.Progress != nil {
+			progress.Current = message.Progress.Current
+			progress.Total = message.Progress.Total
+		}
+
+		if err = progressCb(progress); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Runtime) Pull(ctx context.Context, image string, progressCb containers.PullProgressCallback) error {
+	closer, err := r.dockerClient.ImagePull(ctx, image, types.ImagePullOptions{})
+	if client.IsErrNotFound(err) {
+		return fmt.Errorf("%w: %v", containers.ErrImageNotFound, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	return readPullProgress(closer, progressCb)
+}
+
+func (r *Runtime) Create(ctx context.Context, spec containers.Spec) (string, error) {
+	tcpPortExpose := fmt.Sprintf("%v/tcp", spec.ApiPort)
+	udpPortExpose := fmt.Sprintf("%v/udp", spec.UdpPort)
+
+	timeout := int(spec.StopTimeout / time.Second)
+
+	containerConfig := &container.Config{
+		Image:       spec.Image,
+		StopTimeout: &timeout,
+		ExposedPorts: nat.PortSet{
+			nat.Port(tcpPortExpose): struct{}{},
+			nat.Port(udpPortExpose): struct{}{},
+		},
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          []string{containers.PrefPathEnv + "=" + prefPath},
+		Volumes: map[string]struct{}{
+			dataMountPath: {},
+		},
+		Labels: spec.Labels,
+		Cmd:    spec.Args,
+	}
+	hostConfig := &container.HostConfig{
+		AutoRemove: true,
+		PortBindings: nat.PortMap{
+			nat.Port(tcpPortExpose): []nat.PortBinding{
+				{
+					HostIP:   "127.0.0.1",
+					HostPort: strconv.FormatUint(uint64(spec.ApiPort), 10),
+				},
+			},
+			nat.Port(udpPortExpose): []nat.PortBinding{
+				{
+					HostIP:   "0.0.0.0",
+					HostPort: strconv.FormatUint(uint64(spec.UdpPort), 10),
+				},
+			},
+		},
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeBind,
+				Source: spec.DataPath,
+				Target: dataMountPath,
+			},
+		},
+	}
+	response, err := r.dockerClient.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, spec.Name)
+	if err != nil {
+		return "", err
+	}
+
+	// The game data is shared by all servers so the configuration is copied into the container itself
+	err = r.dockerClient.CopyToContainer(ctx, response.ID, "/", serverConfigArchive(spec), types.CopyToContainerOptions{})
+	if err != nil {
+		if removeErr := r.Remove(ctx, response.ID); removeErr != nil {
+			log.Printf("Failed to remove container %v: %v", response.ID, removeErr)
+		}
+		return "", fmt.Errorf("failed to copy server configuration: %w", err)
+	}
+
+	return response.ID, nil
+}
+
+// serverConfigArchive packs the configuration of the server into a tar archive which is extracted at the root of the
+// container. The directories are writable by everyone since the server stores its pilots next to its configuration
+// and the image may not run as root.
+func serverConfigArchive(spec containers.Spec) io.Reader {
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+
+	configPath := path.Join(prefPath, containers.ServerConfigPath)
+	dirs := strings.Split(path.Dir(configPath), "/")[1:]
+	for i := range dirs {
+		// Errors of the writer are returned by Close
+		_ = writer.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     strings.Join(dirs[:i+1], "/") + "/",
+			Mode:     0777,
+		})
+	}
+
+	config := containers.ServerConfig(spec)
+	_ = writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     strings.TrimPrefix(configPath, "/"),
+		Mode:     0644,
+		Size:     int64(len(config)),
+	})
+	_, _ = writer.Write(config)
+	_ = writer.Close()
+
+	return &archive
+}
+
+// readServerConfig reads the configuration copied into the container by Create
+func (r *Runtime) readServerConfig(ctx context.Context, id string) ([]byte, error) {
+	content, _, err := r.dockerClient.CopyFromContainer(ctx, id, path.Join(prefPath, containers.ServerConfigPath))
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer content.Close()
+
+	archive := tar.NewReader(content)
+	if _, err := archive.Next(); err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(io.LimitReader(archive, maxServerConfigSize))
+}
+
+func (r *Runtime) Start(ctx context.Context, id string, progressCb containers.PullProgressCallback) error {
+	return convertError(r.dockerClient.ContainerStart(ctx, id, types.ContainerStartOptions{}))
+}
+
+func (r *Runtime) Wait(ctx context.Context, id string) (int64, error) {
+	statusCh, errCh := r.dockerClient.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+
+	select {
+	case err := <-errCh:
+		return -1, convertError(err)
+	case waitStat := <-statusCh:
+		if waitStat.Error != nil {
+			return -1, fmt.Errorf("error on container exit: %v", waitStat.Error.Message)
+		}
+		return waitStat.StatusCode, nil
+	}
+}
+
+func (r *Runtime) Stop(ctx context.Context, id string) error {
+	// Without an explicit timeout the stop timeout of the container is used
+	return convertError(r.dockerClient.ContainerStop(ctx, id, nil))
+}
+
+func (r *Runtime) Remove(ctx context.Context, id string) error {
+	return convertError(r.dockerClient.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true}))
+}
+
+func (r *Runtime) WaitForRemoval(ctx context.Context, id string) error {
+	statusCh, errCh := r.dockerClient.ContainerWait(ctx, id, container.WaitConditionRemoved)
+	select {
+	case err := <-errCh:
+		if client.IsErrNotFound(err) {
+			// Already gone
+			return nil
+		}
+		return err
+	case <-statusCh:
+		return nil
+	}
+}
+
+func (r *Runtime) Logs(ctx context.Context, id string) (io.ReadCloser, error) {
+	logs, err := r.dockerClient.ContainerLogs(ctx, id, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	// Containers without a TTY multiplex stdout and stderr into one stream which needs to be split up again
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(writer, writer, logs)
+		_ = logs.Close()
+		_ = writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+func (r *Runtime) Stats(ctx context.Context, id string) (containers.Stats, error) {
+	response, err := r.dockerClient.ContainerStats(ctx, id, false)
+	if err != nil {
+		return containers.Stats{}, convertError(err)
+	}
+	defer response.Body.Close()
+
+	var stats types.StatsJSON
+	if err := json.NewDecoder(response.Body).Decode(&stats); err != nil {
+		return containers.Stats{}, err
+	}
+
+	return containers.Stats{
+		CpuTime:     time.Duration(stats.CPUStats.CPUUsage.TotalUsage),
+		MemoryUsage: stats.MemoryStats.Usage,
+		MemoryLimit: stats.MemoryStats.Limit,
+	}, nil
+}
+
+// hostPort finds the host port a container port of the specified protocol is bound to
+func hostPort(bindings nat.PortMap, proto string) uint16 {
+	for port, portBindings := range bindings {
+		if port.Proto() != proto || len(portBindings) == 0 {
+			continue
+		}
+
+		hostPort, err := strconv.ParseUint(portBindings[0].HostPort, 10, 16)
+		if err != nil {
+			return 0
+		}
+		return uint16(hostPort)
+	}
+
+	return 0
+}
+
+func (r *Runtime) Inspect(ctx context.Context, id string) (containers.Info, error) {
+	info, err := r.dockerClient.ContainerInspect(ctx, id)
+	if err != nil {
+		return containers.Info{}, convertError(err)
+	}
+
+	containerInfo := containers.Info{
+		Id:      info.ID,
+		Image:   info.Config.Image,
+		Labels:  info.Config.Labels,
+		ApiPort: hostPort(info.HostConfig.PortBindings, "tcp"),
+		UdpPort: hostPort(info.HostConfig.PortBindings, "udp"),
+	}
+
+	config, err := r.readServerConfig(ctx, id)
+	if err != nil {
+		log.Printf("Failed to read server configuration of container %v: %v", id, err)
+	} else {
+		containerInfo.ApiUsername, containerInfo.ApiPassword = containers.ParseServerConfig(config)
+	}
+
+	return containerInfo, nil
+}
+
+func (r *Runtime) List(ctx context.Context, label string) ([]string, error) {
+	dockerContainers, err := r.dockerClient.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.KeyValuePair{
+			Key:   "label",
+			Value: label,
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(dockerContainers))
+	for _, dockerContainer := range dockerContainers {
+		ids = append(ids, dockerContainer.ID)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+)
+
+func TestServerConfigArchive(t *testing.T) {
+	spec := containers.Spec{ApiPort: 8080, ApiUsername: "user", ApiPassword: "secret"}
+
+	archive := tar.NewReader(serverConfigArchive(spec))
+
+	var names []string
+	var config []byte
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid archive: %v", err)
+		}
+
+		names = append(names, header.Name)
+		if header.Typeflag == tar.TypeDir && header.Mode != 0777 {
+			t.Errorf("expected %v to be writable by the server", header.Name)
+		}
+		if header.Typeflag == tar.TypeReg {
+			config, _ = ioutil.ReadAll(archive)
+		}
+	}
+
+	expected := []string{
+		"commnode/",
+		"commnode/HardLightProductions/",
+		"commnode/HardLightProductions/FreeSpaceOpen/",
+		"commnode/HardLightProductions/FreeSpaceOpen/data/",
+		"commnode/HardLightProductions/FreeSpaceOpen/data/multi.cfg",
+	}
+	if len(names) != len(expected) {
+		t.Fatalf("expected entries %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("expected entry %v, got %v", expected[i], names[i])
+		}
+	}
+
+	if username, password := containers.ParseServerConfig(config); username != "user" || password != "secret" {
+		t.Errorf("unexpected credentials in the archive: %q", config)
+	}
+}
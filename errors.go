@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
+	pb "github.com/scp-fs2open/CommnodeWorker/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	errorDomain = "commnode.worker"
+
+	// How long clients should wait before retrying a start which failed due to a temporary problem
+	startRetryDelay = time.Second * 30
+)
+
+// Machine readable reasons for failed server starts
+const (
+	reasonWorkerFull        = "WORKER_FULL"
+	reasonImagePullFailed   = "IMAGE_PULL_FAILED"
+	reasonImageNotFound     = "IMAGE_NOT_FOUND"
+	reasonContainerFailed   = "CONTAINER_START_FAILED"
+	reasonServerApiTimeout  = "SERVER_API_TIMEOUT"
+	reasonServerSetupFailed = "SERVER_SETUP_FAILED"
+	reasonServerAuthFailed  = "SERVER_AUTH_FAILED"
+	reasonStartCanceled     = "START_CANCELED"
+)
+
+var reasonCodes = map[string]codes.Code{
+	reasonWorkerFull:        codes.ResourceExhausted,
+	reasonImagePullFailed:   codes.Unavailable,
+	reasonImageNotFound:     codes.FailedPrecondition,
+	reasonContainerFailed:   codes.Internal,
+	reasonServerApiTimeout:  codes.DeadlineExceeded,
+	reasonServerSetupFailed: codes.Unavailable,
+	reasonServerAuthFailed:  codes.Internal,
+	reasonStartCanceled:     codes.Canceled,
+}
+
+// startError describes why a server could not be started. It carries everything needed for the final Failed event
+// and the gRPC status returned to the client.
+type startError struct {
+	phase  pb.FailedPayload_Phase
+	reason string
+	code   codes.Code
+	err    error
+}
+
+// newStartError classifies the error which stopped a start. Errors which say more than the phase decide the reason
+// on their own.
+func newStartError(phase pb.FailedPayload_Phase, err error) *startError {
+	reason := reasonServerSetupFailed
+
+	switch {
+	case errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled:
+		reason = reasonStartCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		reason = reasonServerApiTimeout
+	case errors.Is(err, fsoApi.ErrUnauthorized):
+		// The server does not accept the credentials it was given so this is our fault and retrying will not help
+		reason = reasonServerAuthFailed
+	case errors.Is(err, containers.ErrImageNotFound):
+		// The configured image is wrong so retrying will not help either
+		reason = reasonImageNotFound
+	default:
+		switch phase {
+		case pb.FailedPayload_Allocation:
+			reason = reasonWorkerFull
+		case pb.FailedPayload_ImagePull:
+			reason = reasonImagePullFailed
+		case pb.FailedPayload_ContainerStart:
+			reason = reasonContainerFailed
+		}
+	}
+
+	return &startError{phase: phase, reason: reason, code: reasonCodes[reason], err: err}
+}
+
+// startErrorFromPayload restores the error which caused a Failed event
+func startErrorFromPayload(payload *pb.FailedPayload) *startError {
+	code, ok := reasonCodes[payload.GetReason()]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	return &startError{
+		phase:  payload.GetPhase(),
+		reason: payload.GetReason(),
+		code:   code,
+		err:    errors.New(payload.GetMessage()),
+	}
+}
+
+// streamError converts errors of streaming RPCs to gRPC status errors. Errors from sending are already status errors
+// but context errors need to be converted.
+func streamError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	return err
+}
+
+func (e *startError) Error() string {
+	return e.err.Error()
+}
+
+func (e *startError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus converts the error to a gRPC status with error details attached
+func (e *startError) GRPCStatus() *status.Status {
+	st := status.New(e.code, e.err.Error())
+
+	details := []proto.Message{
+		&errdetails.ErrorInfo{
+			Reason: e.reason,
+			Domain: errorDomain,
+			Metadata: map[string]string{
+				"phase": e.phase.String(),
+			},
+		},
+	}
+	if e.code == codes.Unavailable || e.code == codes.ResourceExhausted {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(startRetryDelay)})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		// The details are only a nice to have so fall back to the plain status
+		return st
+	}
+
+	return withDetails
+}
+
+func (e *startError) failedEvent() *pb.ServerEvent {
+	return &pb.ServerEvent{
+		Type:    pb.ServerEvent_Failed,
+		Message: e.err.Error(),
+		Payload: &pb.ServerEvent_Failure{Failure: &pb.FailedPayload{
+			Message: e.err.Error(),
+			Phase:   e.phase,
+			Reason:  e.reason,
+		}},
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
+	pb "github.com/scp-fs2open/CommnodeWorker/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewStartError(t *testing.T) {
+	failure := errors.New("something broke")
+
+	tests := []struct {
+		name   string
+		phase  pb.FailedPayload_Phase
+		err    error
+		reason string
+		code   codes.Code
+		retry  bool
+	}{
+		{"worker full", pb.FailedPayload_Allocation, failure, reasonWorkerFull, codes.ResourceExhausted, true},
+		{"pull", pb.FailedPayload_ImagePull, failure, reasonImagePullFailed, codes.Unavailable, true},
+		{"unknown image", pb.FailedPayload_ImagePull, fmt.Errorf("%w: manifest unknown", containers.ErrImageNotFound), reasonImageNotFound, codes.FailedPrecondition, false},
+		{"container", pb.FailedPayload_ContainerStart, failure, reasonContainerFailed, codes.Internal, false},
+		{"online timeout", pb.FailedPayload_WaitForOnline, fmt.Errorf("%w after 3 attempts: %w", context.DeadlineExceeded, failure), reasonServerApiTimeout, codes.DeadlineExceeded, false},
+		{"server gone while waiting", pb.FailedPayload_WaitForOnline, failure, reasonServerSetupFailed, codes.Unavailable, true},
+		{"credentials rejected", pb.FailedPayload_WaitForOnline, &fsoApi.APIError{StatusCode: http.StatusUnauthorized}, reasonServerAuthFailed, codes.Internal, false},
+		{"setup", pb.FailedPayload_ServerSetup, failure, reasonServerSetupFailed, codes.Unavailable, true},
+		{"canceled", pb.FailedPayload_ImagePull, context.Canceled, reasonStartCanceled, codes.Canceled, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			startErr := newStartError(test.phase, test.err)
+			if startErr.reason != test.reason || startErr.code != test.code {
+				t.Errorf("expected %v with code %v, got %v with %v", test.reason, test.code, startErr.reason, startErr.code)
+			}
+
+			retry := false
+			for _, detail := range startErr.GRPCStatus().Details() {
+				if _, ok := detail.(*errdetails.RetryInfo); ok {
+					retry = true
+				}
+			}
+			if retry != test.retry {
+				t.Errorf("expected retry info %v, got %v", test.retry, retry)
+			}
+		})
+	}
+}
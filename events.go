@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
+	pb "github.com/scp-fs2open/CommnodeWorker/grpc"
+	"github.com/scp-fs2open/CommnodeWorker/servers"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventPublisher stamps the events of a server with the server ID, a timestamp and a sequence number and records
+// them in the event log of the server
+type eventPublisher struct {
+	mutex    sync.Mutex
+	log      *servers.EventLog
+	serverId string
+	sequence uint64
+}
+
+func newEventPublisher(server *servers.Server) *eventPublisher {
+	return &eventPublisher{
+		log:      server.Events,
+		serverId: server.Id,
+	}
+}
+
+func (e *eventPublisher) publish(event *pb.ServerEvent) {
+	// The lock ensures that the order in the log matches the sequence numbers
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.sequence += 1
+
+	event.ServerId = e.serverId
+	event.Sequence = e.sequence
+	event.Timestamp = timestamppb.Now()
+
+	e.log.Publish(event)
+}
+
+// followEvents passes all events of the log to the handler until the handler reports that it is done, the log is
+// closed or the context is done
+func followEvents(ctx context.Context, log *servers.EventLog, handler func(event *pb.ServerEvent) (done bool, err error)) error {
+	next := 0
+	for {
+		events, closed, err := log.Read(ctx, next)
+		if err != nil {
+			return err
+		}
+		next += len(events)
+
+		for _, event := range events {
+			done, err := handler(event.(*pb.ServerEvent))
+			if err != nil || done {
+				return err
+			}
+		}
+
+		if closed {
+			return nil
+		}
+	}
+}
+
+func convertPlayer(player fsoApi.PlayerData) *pb.PlayerInfo {
+	return &pb.PlayerInfo{
+		Callsign: player.Callsign,
+		Ping:     player.Ping,
+		Host:     player.Host,
+		Observer: player.Observer,
+		Ship:     player.Ship,
+	}
+}
+
+func convertStopReason(reason servers.StopReason) pb.StoppedPayload_Reason {
+	switch reason {
+	case servers.StopReasonRequested:
+		return pb.StoppedPayload_Requested
+	case servers.StopReasonShutdown:
+		return pb.StoppedPayload_WorkerShutdown
+	case servers.StopReasonExited:
+		return pb.StoppedPayload_Exited
+	default:
+		return pb.StoppedPayload_Idle
+	}
+}
+
+func convertLifecycleEvent(event servers.LifecycleEvent) *pb.ServerEvent {
+	switch event.Type {
+	case servers.LifecyclePlayerJoined:
+		return &pb.ServerEvent{
+			Type:    pb.ServerEvent_PlayerJoined,
+			Message: event.Player.Callsign,
+			Payload: &pb.ServerEvent_Player{Player: convertPlayer(event.Player)},
+		}
+	case servers.LifecyclePlayerLeft:
+		return &pb.ServerEvent{
+			Type:    pb.ServerEvent_PlayerLeft,
+			Message: event.Player.Callsign,
+			Payload: &pb.ServerEvent_Player{Player: convertPlayer(event.Player)},
+		}
+	case servers.LifecycleIdleWarning:
+		return &pb.ServerEvent{
+			Type:    pb.ServerEvent_IdleWarning,
+			Message: fmt.Sprintf("Server will shut down in %v without players", event.IdleTimeLeft.Round(time.Second)),
+			Payload: &pb.ServerEvent_Idle{Idle: &pb.IdleWarningPayload{
+				IdleTime: durationpb.New(event.IdleTime),
+				TimeLeft: durationpb.New(event.IdleTimeLeft),
+			}},
+		}
+	default:
+		reason := convertStopReason(event.StopReason)
+		return &pb.ServerEvent{
+			Type:    pb.ServerEvent_Stopped,
+			Message: fmt.Sprintf("Server stopped (%v) with exit code %v", reason, event.ExitCode),
+			Payload: &pb.ServerEvent_Stop{Stop: &pb.StoppedPayload{
+				Reason:   reason,
+				ExitCode: event.ExitCode,
+			}},
+		}
+	}
+}
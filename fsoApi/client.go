@@ -4,62 +4,72 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 const (
-	baseURLFormat = "http://127.0.0.1:%v/api/1/"
+	apiPath       = "/api/1/"
+	baseURLFormat = "http://127.0.0.1:%v" + apiPath
 )
 
+// Credentials are used for authenticating against the API of a server
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// DialFunc opens a connection to the server API. Can be used for reaching servers on other hosts.
+type DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error)
+
 // Client is a FSO server API client
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	credentials Credentials
+	httpClient  *http.Client
 }
 
-// NewClient creates a new FSO API client
-func NewClient(port uint16) *Client {
-	return &Client{
-		baseURL: fmt.Sprintf(baseURLFormat, port),
-		httpClient: &http.Client{
-			Timeout: time.Minute,
-		},
-	}
+// NewClient creates a new FSO API client which authenticates with the specified credentials
+func NewClient(port uint16, credentials Credentials) *Client {
+	return NewClientWithDialer(port, credentials, nil)
 }
 
-func (c *Client) sendRequest(req *http.Request) error {
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("Accept", "application/json; charset=utf-8")
-	req.SetBasicAuth("admin", "admin")
-
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
+// NewClientWithDialer creates a new FSO API client which connects to the server through the specified function. The
+// default dialer is used if it is nil.
+func NewClientWithDialer(port uint16, credentials Credentials, dial DialFunc) *Client {
+	httpClient := &http.Client{
+		Timeout: time.Minute,
 	}
-
-	err = res.Body.Close()
-	if err != nil {
-		return err
+	if dial != nil {
+		httpClient.Transport = &http.Transport{
+			// No proxy
+			DialContext: dial,
+		}
 	}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return errors.New(fmt.Sprintf("HTTP call failed with code %v", res.StatusCode))
+	return &Client{
+		baseURL:     fmt.Sprintf(baseURLFormat, port),
+		credentials: credentials,
+		httpClient:  httpClient,
 	}
+}
 
-	return nil
+func (c *Client) sendRequest(req *http.Request) error {
+	return c.sendRequestWithResponse(req, nil)
 }
 
+// sendRequestWithResponse sends the request and decodes the JSON response into v unless it is nil
 func (c *Client) sendRequestWithResponse(req *http.Request, v interface{}) (err error) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.Header.Set("Accept", "application/json; charset=utf-8")
-	req.SetBasicAuth("admin", "admin")
+	req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return
+		return convertRequestError(req.Context(), err)
 	}
 	defer func() {
 		closeErr := res.Body.Close()
@@ -70,34 +80,44 @@ func (c *Client) sendRequestWithResponse(req *http.Request, v interface{}) (err
 	}()
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return errors.New(fmt.Sprintf("HTTP call failed with code %v", res.StatusCode))
+		return newAPIError(req, res)
 	}
 
-	if err = json.NewDecoder(res.Body).Decode(&v); err != nil {
-		return
+	if v == nil {
+		return nil
 	}
 
-	return nil
+	return json.NewDecoder(res.Body).Decode(v)
 }
 
 func (c *Client) getUrl(apiFunc string) string {
 	return c.baseURL + apiFunc
 }
 
+// serverData is the wire format of the server settings. The server reads the frame cap as a string.
 type serverData struct {
 	Name     string `json:"name,omitempty"`
 	Password string `json:"password,omitempty"`
 	FrameCap string `json:"framecap,omitempty"`
 }
 
-func (c *Client) WaitForOnline(ctx context.Context, timeout time.Duration) error {
-	start := time.Now()
-	for {
-		since := time.Since(start)
-		if since >= timeout {
-			return errors.New("Timeout ocurred.")
-		}
+// ServerSettings contains the settings of a standalone server which can be changed through the API. Empty values
+// leave the current setting unchanged.
+type ServerSettings struct {
+	Name     string
+	Password string
+
+	// Maximum frame rate of the server. Zero keeps the current frame cap.
+	FrameCap int
+}
 
+// WaitForOnline polls the server until its API accepts our credentials. The attempt callback may be nil. Returns an
+// error wrapping context.DeadlineExceeded if the server is not online within the timeout.
+func (c *Client) WaitForOnline(ctx context.Context, timeout time.Duration, attemptCb AttemptCallback) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return Retry(ctx, DefaultBackoff, attemptCb, func(ctx context.Context) error {
 		req, err := http.NewRequest(http.MethodGet, c.getUrl("auth"), nil)
 		if err != nil {
 			return err
@@ -105,18 +125,21 @@ func (c *Client) WaitForOnline(ctx context.Context, timeout time.Duration) error
 
 		req = req.WithContext(ctx)
 
-		if err := c.sendRequest(req); err == nil {
-			return nil
-		}
-	}
+		return c.sendRequest(req)
+	})
 }
 
-// SetServerName updates the name of the server to the specified value
-func (c *Client) SetServerName(ctx context.Context, name string) error {
+// UpdateServer applies the specified settings to the server
+func (c *Client) UpdateServer(ctx context.Context, settings ServerSettings) error {
 	serverJson, err := json.Marshal(serverData{
-		Name:     name,
-		FrameCap: "0", // Needs to be set to avoid crashing the server...
+		Name:     settings.Name,
+		Password: settings.Password,
+		// Needs to be set to avoid crashing the server. The server ignores a value of zero.
+		FrameCap: strconv.Itoa(settings.FrameCap),
 	})
+	if err != nil {
+		return err
+	}
 
 	req, err := http.NewRequest(http.MethodPut, c.getUrl("server"), bytes.NewBuffer(serverJson))
 	if err != nil {
@@ -132,6 +155,11 @@ func (c *Client) SetServerName(ctx context.Context, name string) error {
 	return nil
 }
 
+// SetServerName updates the name of the server to the specified value
+func (c *Client) SetServerName(ctx context.Context, name string) error {
+	return c.UpdateServer(ctx, ServerSettings{Name: name})
+}
+
 type PlayerData struct {
 	Id       int32  `json:"id,omitempty"`
 	Address  string `json:"address,omitempty"`
@@ -142,7 +170,7 @@ type PlayerData struct {
 	Ship     string `json:"ship,omitempty"`
 }
 
-// SetServerName updates the name of the server to the specified value
+// GetPlayers returns the players currently connected to the server
 func (c *Client) GetPlayers(ctx context.Context) ([]PlayerData, error) {
 	req, err := http.NewRequest(http.MethodGet, c.getUrl("player"), nil)
 	if err != nil {
@@ -0,0 +1,84 @@
+package fsoApi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testCredentials = Credentials{Username: "user", Password: "secret"}
+
+// newTestClient returns a client which sends all its requests to the handler regardless of the port
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClientWithDialer(8080, testCredentials, func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, server.Listener.Addr().String())
+	})
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func TestWaitForOnline(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+			t.Errorf("unexpected credentials %v:%v", username, password)
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/api/1/auth" {
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+		}
+
+		if requests < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	var attempts int
+	err := client.WaitForOnline(context.Background(), time.Second*5, func(attempt int) {
+		attempts = attempt
+	})
+
+	if err != nil {
+		t.Fatalf("expected the server to come online: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %v", attempts)
+	}
+}
+
+func TestWaitForOnlineUnauthorized(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "wrong credentials", http.StatusUnauthorized)
+	})
+
+	start := time.Now()
+	err := client.WaitForOnline(context.Background(), time.Second*5, nil)
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+	if requests != 1 || time.Since(start) > time.Second {
+		t.Errorf("expected WaitForOnline to give up right away, sent %v requests", requests)
+	}
+}
+
+func TestWaitForOnlineTimeout(t *testing.T) {
+	client := NewClient(1, testCredentials)
+
+	err := client.WaitForOnline(context.Background(), time.Millisecond*300, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrServerUnreachable) {
+		t.Errorf("expected a deadline error wrapping the unreachable server, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package fsoApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// How much of the body of a failed response is kept for the error
+	maxBodyExcerpt = 512
+)
+
+var (
+	// ErrUnauthorized is matched by API errors if the server rejected our credentials
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrNotFound is matched by API errors if the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrServerUnreachable is matched by errors of requests which could not reach the server at all, e.g. because its
+	// container is gone
+	ErrServerUnreachable = errors.New("server unreachable")
+)
+
+// APIError is returned if the server answered a request with an error status
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+
+	// The beginning of the response body
+	Body string
+
+	// Whether repeating the request later might succeed
+	Retryable bool
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("%v %v failed with code %v", e.Method, e.Endpoint, e.StatusCode)
+	if e.Body != "" {
+		message += ": " + e.Body
+	}
+
+	return message
+}
+
+// Is makes the error match ErrUnauthorized and ErrNotFound depending on its status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
+
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	case http.StatusNotImplemented:
+		return false
+	}
+
+	return statusCode >= 500
+}
+
+func newAPIError(req *http.Request, res *http.Response) *APIError {
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxBodyExcerpt))
+
+	return &APIError{
+		StatusCode: res.StatusCode,
+		Method:     req.Method,
+		Endpoint:   strings.TrimPrefix(req.URL.Path, apiPath),
+		Body:       strings.TrimSpace(string(body)),
+		Retryable:  isRetryableStatus(res.StatusCode),
+	}
+}
+
+// convertRequestError marks errors of requests which did not reach the server. Timeouts are left alone since a slow
+// server is still there.
+func convertRequestError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrServerUnreachable, err)
+}
+
+// IsRetryable checks if repeating a failed request later might succeed
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// The server might still be starting
+	return errors.Is(err, ErrServerUnreachable)
+}
@@ -0,0 +1,125 @@
+package fsoApi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		statusCode   int
+		unauthorized bool
+		notFound     bool
+	}{
+		{http.StatusUnauthorized, true, false},
+		{http.StatusForbidden, true, false},
+		{http.StatusNotFound, false, true},
+		{http.StatusBadRequest, false, false},
+		{http.StatusInternalServerError, false, false},
+	}
+
+	for _, test := range tests {
+		var err error = &APIError{StatusCode: test.statusCode}
+
+		if errors.Is(err, ErrUnauthorized) != test.unauthorized {
+			t.Errorf("status %v: expected errors.Is(ErrUnauthorized) to be %v", test.statusCode, test.unauthorized)
+		}
+		if errors.Is(err, ErrNotFound) != test.notFound {
+			t.Errorf("status %v: expected errors.Is(ErrNotFound) to be %v", test.statusCode, test.notFound)
+		}
+		if errors.Is(err, ErrServerUnreachable) {
+			t.Errorf("status %v: an answered request must not count as unreachable", test.statusCode)
+		}
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPut, "http://127.0.0.1:8080/api/1/server", nil)
+	res := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       ioutil.NopCloser(strings.NewReader(" " + strings.Repeat("x", maxBodyExcerpt*2))),
+	}
+
+	err := newAPIError(req, res)
+
+	if err.Method != http.MethodPut || err.Endpoint != "server" || err.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected error details: %+v", err)
+	}
+	if len(err.Body) != maxBodyExcerpt-1 {
+		t.Errorf("expected the body to be cut to the excerpt, got %v bytes", len(err.Body))
+	}
+	if !err.Retryable {
+		t.Error("expected 503 to be retryable")
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	retryable := []int{http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable}
+	permanent := []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+		http.StatusNotImplemented}
+
+	for _, statusCode := range retryable {
+		if !isRetryableStatus(statusCode) {
+			t.Errorf("expected status %v to be retryable", statusCode)
+		}
+	}
+	for _, statusCode := range permanent {
+		if isRetryableStatus(statusCode) {
+			t.Errorf("expected status %v not to be retryable", statusCode)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestConvertRequestError(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	err := convertRequestError(context.Background(), refused)
+	if !errors.Is(err, ErrServerUnreachable) {
+		t.Errorf("expected a refused connection to be unreachable: %v", err)
+	}
+	if !errors.Is(err, refused) {
+		t.Errorf("expected the original error to stay wrapped: %v", err)
+	}
+	if !IsRetryable(err) {
+		t.Error("expected an unreachable server to be retryable")
+	}
+
+	err = convertRequestError(context.Background(), timeoutError{})
+	if errors.Is(err, ErrServerUnreachable) {
+		t.Errorf("a timeout must not count as unreachable: %v", err)
+	}
+	if !IsRetryable(err) {
+		t.Error("expected a timeout to be retryable")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = convertRequestError(ctx, refused)
+	if errors.Is(err, ErrServerUnreachable) {
+		t.Errorf("errors after the context ended must be left alone: %v", err)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if IsRetryable(&APIError{StatusCode: http.StatusUnauthorized}) {
+		t.Error("expected 401 not to be retryable")
+	}
+	if !IsRetryable(&APIError{StatusCode: http.StatusBadGateway, Retryable: true}) {
+		t.Error("expected a retryable API error to be retryable")
+	}
+	if IsRetryable(errors.New("something else")) {
+		t.Error("expected unknown errors not to be retryable")
+	}
+}
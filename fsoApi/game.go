@@ -0,0 +1,142 @@
+package fsoApi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// newRequest creates a request for an API function. The body is encoded as JSON unless it is nil.
+func (c *Client) newRequest(ctx context.Context, method string, apiFunc string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewBuffer(content)
+	}
+
+	req, err := http.NewRequest(method, c.getUrl(apiFunc), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return req.WithContext(ctx), nil
+}
+
+// MissionInfo describes the mission which is currently played
+type MissionInfo struct {
+	Name     string `json:"name,omitempty"`
+	Filename string `json:"filename,omitempty"`
+
+	// Time since the start of the mission in seconds
+	GameTime float64 `json:"gametime,omitempty"`
+
+	// Time since the start of the server in seconds
+	Timestamp float64 `json:"timestamp,omitempty"`
+}
+
+// GetMission returns the mission which is currently played
+func (c *Client) GetMission(ctx context.Context) (MissionInfo, error) {
+	var mission MissionInfo
+
+	req, err := c.newRequest(ctx, http.MethodGet, "mission", nil)
+	if err != nil {
+		return mission, err
+	}
+
+	err = c.sendRequestWithResponse(req, &mission)
+	return mission, err
+}
+
+// NetgameInfo describes the game hosted by the server
+type NetgameInfo struct {
+	Name         string `json:"name,omitempty"`
+	Mission      string `json:"mission,omitempty"`
+	Campaign     string `json:"campaign,omitempty"`
+	MaxPlayers   int32  `json:"maxPlayers,omitempty"`
+	MaxObservers int32  `json:"maxObservers,omitempty"`
+	Respawn      int32  `json:"respawn,omitempty"`
+
+	// Raw values of the game state and security level as used by the server
+	GameState int32 `json:"gameState,omitempty"`
+	Security  int32 `json:"security,omitempty"`
+}
+
+// GetNetgame returns the game hosted by the server
+func (c *Client) GetNetgame(ctx context.Context) (NetgameInfo, error) {
+	var netgame NetgameInfo
+
+	req, err := c.newRequest(ctx, http.MethodGet, "netgame", nil)
+	if err != nil {
+		return netgame, err
+	}
+
+	err = c.sendRequestWithResponse(req, &netgame)
+	return netgame, err
+}
+
+// ChatMessage is a message of the in-game chat
+type ChatMessage struct {
+	Message string `json:"message,omitempty"`
+}
+
+// GetChat returns the recent messages of the in-game chat
+func (c *Client) GetChat(ctx context.Context) ([]ChatMessage, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "chat", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []ChatMessage
+
+	if err := c.sendRequestWithResponse(req, &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// SendChat sends a message to all players in the game
+func (c *Client) SendChat(ctx context.Context, message string) error {
+	req, err := c.newRequest(ctx, http.MethodPost, "chat", ChatMessage{Message: message})
+	if err != nil {
+		return err
+	}
+
+	return c.sendRequest(req)
+}
+
+// KickPlayer removes the player with the specified ID from the game
+func (c *Client) KickPlayer(ctx context.Context, playerId int32) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("player/%v", playerId), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.sendRequest(req)
+}
+
+// ResetGame ends the current game and returns the server to its initial state
+func (c *Client) ResetGame(ctx context.Context) error {
+	req, err := c.newRequest(ctx, http.MethodPost, "server/resetGame", nil)
+	if err != nil {
+		return err
+	}
+
+	return c.sendRequest(req)
+}
+
+// ShutdownServer makes the server exit
+func (c *Client) ShutdownServer(ctx context.Context) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, "server", nil)
+	if err != nil {
+		return err
+	}
+
+	return c.sendRequest(req)
+}
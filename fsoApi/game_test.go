@@ -0,0 +1,201 @@
+package fsoApi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// apiCall describes the request expected for a client method and the response the server sends
+type apiCall struct {
+	name     string
+	method   string
+	path     string
+	body     string
+	response string
+	call     func(ctx context.Context, c *Client) (interface{}, error)
+	result   interface{}
+}
+
+var apiCalls = []apiCall{
+	{
+		name:   "UpdateServer",
+		method: http.MethodPut,
+		path:   "/api/1/server",
+		body:   `{"name":"Commnode: Test","framecap":"0"}`,
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return nil, c.UpdateServer(ctx, ServerSettings{Name: "Commnode: Test"})
+		},
+	},
+	{
+		name:     "GetPlayers",
+		method:   http.MethodGet,
+		path:     "/api/1/player",
+		response: `[{"id":3,"callsign":"Alpha 1","host":true}]`,
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return c.GetPlayers(ctx)
+		},
+		result: []PlayerData{{Id: 3, Callsign: "Alpha 1", Host: true}},
+	},
+	{
+		name:     "GetMission",
+		method:   http.MethodGet,
+		path:     "/api/1/mission",
+		response: `{"name":"Into the Lion's Den","filename":"sm1-01.fs2","gametime":12.5}`,
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return c.GetMission(ctx)
+		},
+		result: MissionInfo{Name: "Into the Lion's Den", Filename: "sm1-01.fs2", GameTime: 12.5},
+	},
+	{
+		name:     "GetNetgame",
+		method:   http.MethodGet,
+		path:     "/api/1/netgame",
+		response: `{"name":"Test","maxPlayers":12,"maxObservers":4,"gameState":2}`,
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return c.GetNetgame(ctx)
+		},
+		result: NetgameInfo{Name: "Test", MaxPlayers: 12, MaxObservers: 4, GameState: 2},
+	},
+	{
+		name:     "GetChat",
+		method:   http.MethodGet,
+		path:     "/api/1/chat",
+		response: `[{"message":"hello"},{"message":"gg"}]`,
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return c.GetChat(ctx)
+		},
+		result: []ChatMessage{{Message: "hello"}, {Message: "gg"}},
+	},
+	{
+		name:   "SendChat",
+		method: http.MethodPost,
+		path:   "/api/1/chat",
+		body:   `{"message":"server restarts soon"}`,
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return nil, c.SendChat(ctx, "server restarts soon")
+		},
+	},
+	{
+		name:   "KickPlayer",
+		method: http.MethodDelete,
+		path:   "/api/1/player/7",
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return nil, c.KickPlayer(ctx, 7)
+		},
+	},
+	{
+		name:   "ResetGame",
+		method: http.MethodPost,
+		path:   "/api/1/server/resetGame",
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return nil, c.ResetGame(ctx)
+		},
+	},
+	{
+		name:   "ShutdownServer",
+		method: http.MethodDelete,
+		path:   "/api/1/server",
+		call: func(ctx context.Context, c *Client) (interface{}, error) {
+			return nil, c.ShutdownServer(ctx)
+		},
+	},
+}
+
+// jsonEqual compares two JSON documents independent of their formatting
+func jsonEqual(t *testing.T, expected string, actual []byte) bool {
+	var expectedValue, actualValue interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(expectedValue, actualValue)
+}
+
+func TestApiRequests(t *testing.T) {
+	for _, test := range apiCalls {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != test.method || r.URL.Path != test.path {
+					t.Errorf("expected %v %v, got %v %v", test.method, test.path, r.Method, r.URL.Path)
+				}
+				if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+					t.Errorf("unexpected credentials %v:%v", username, password)
+				}
+
+				body, _ := ioutil.ReadAll(r.Body)
+				if test.body == "" && len(body) != 0 {
+					t.Errorf("expected no body, got %s", body)
+				}
+				if test.body != "" && !jsonEqual(t, test.body, body) {
+					t.Errorf("expected body %v, got %s", test.body, body)
+				}
+
+				_, _ = w.Write([]byte(test.response))
+			})
+
+			result, err := test.call(context.Background(), client)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if test.result != nil && !reflect.DeepEqual(result, test.result) {
+				t.Errorf("expected %+v, got %+v", test.result, result)
+			}
+		})
+	}
+}
+
+func TestApiErrors(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		target     error
+		retryable  bool
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized, false},
+		{http.StatusNotFound, ErrNotFound, false},
+		{http.StatusServiceUnavailable, nil, true},
+		{http.StatusNotImplemented, nil, false},
+	}
+
+	for _, call := range apiCalls {
+		for _, test := range tests {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "something went wrong", test.statusCode)
+			})
+
+			_, err := call.call(context.Background(), client)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("%v: expected an API error for status %v, got %v", call.name, test.statusCode, err)
+			}
+			if apiErr.StatusCode != test.statusCode || apiErr.Method != call.method ||
+				"/api/1/"+apiErr.Endpoint != call.path || apiErr.Body != "something went wrong" {
+				t.Errorf("%v: unexpected error details %+v", call.name, apiErr)
+			}
+			if test.target != nil && !errors.Is(err, test.target) {
+				t.Errorf("%v: expected status %v to match %v", call.name, test.statusCode, test.target)
+			}
+			if IsRetryable(err) != test.retryable {
+				t.Errorf("%v: expected retryable to be %v for status %v", call.name, test.retryable, test.statusCode)
+			}
+		}
+	}
+}
+
+func TestApiUnreachable(t *testing.T) {
+	client := NewClient(1, testCredentials)
+
+	for _, call := range apiCalls {
+		if _, err := call.call(context.Background(), client); !errors.Is(err, ErrServerUnreachable) {
+			t.Errorf("%v: expected the server to be unreachable, got %v", call.name, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package fsoApi
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Backoff describes how long to wait between two attempts of an operation
+type Backoff struct {
+	// Delay after the first attempt
+	Initial time.Duration
+
+	// Upper limit of the delay
+	Max time.Duration
+
+	// The delay is multiplied by this after every attempt
+	Factor float64
+
+	// Fraction of the delay which is randomized so that several clients do not retry in lockstep
+	Jitter float64
+}
+
+// DefaultBackoff is used for waiting until a server is reachable
+var DefaultBackoff = Backoff{
+	Initial: time.Millisecond * 100,
+	Max:     time.Second * 5,
+	Factor:  2,
+	Jitter:  0.2,
+}
+
+// Delay returns how long to wait after the specified attempt. Attempts are counted from 1.
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := float64(b.Initial)
+	for i := 1; i < attempt && delay < float64(b.Max); i++ {
+		delay *= b.Factor
+	}
+	if delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+
+	delay *= 1 + b.Jitter*(2*rand.Float64()-1)
+
+	return time.Duration(delay)
+}
+
+// AttemptCallback is called before every attempt with the number of the attempt, counted from 1
+type AttemptCallback = func(attempt int)
+
+// Retry calls the operation until it succeeds, fails with an error which is not retryable or the context is done. The
+// attempt callback may be nil. If the context ends first, the returned error wraps both the context error and the
+// error of the last attempt.
+func Retry(ctx context.Context, backoff Backoff, attemptCb AttemptCallback, operation func(ctx context.Context) error) error {
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return retryError(err, attempt-1, lastErr)
+		}
+
+		if attemptCb != nil {
+			attemptCb(attempt)
+		}
+
+		lastErr = operation(ctx)
+		if lastErr == nil {
+			return nil
+		}
+		if ctx.Err() == nil && !IsRetryable(lastErr) {
+			// E.g. wrong credentials which will not get any better by trying again
+			return lastErr
+		}
+
+		timer := time.NewTimer(backoff.Delay(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return retryError(ctx.Err(), attempt, lastErr)
+		}
+	}
+}
+
+func retryError(ctxErr error, attempts int, lastErr error) error {
+	if lastErr == nil {
+		return ctxErr
+	}
+
+	return fmt.Errorf("%w after %v attempts: %w", ctxErr, attempts, lastErr)
+}
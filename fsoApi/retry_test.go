@@ -0,0 +1,126 @@
+package fsoApi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var testBackoff = Backoff{
+	Initial: time.Millisecond,
+	Max:     time.Millisecond * 10,
+	Factor:  2,
+}
+
+func TestBackoffDelay(t *testing.T) {
+	backoff := Backoff{Initial: time.Millisecond * 100, Max: time.Second, Factor: 2}
+
+	expected := []time.Duration{
+		time.Millisecond * 100,
+		time.Millisecond * 200,
+		time.Millisecond * 400,
+		time.Millisecond * 800,
+		time.Second,
+		time.Second,
+	}
+	for i, delay := range expected {
+		if actual := backoff.Delay(i + 1); actual != delay {
+			t.Errorf("attempt %v: expected %v, got %v", i+1, delay, actual)
+		}
+	}
+
+	backoff.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := backoff.Delay(1)
+		if delay < time.Millisecond*50 || delay > time.Millisecond*150 {
+			t.Fatalf("delay %v is outside of the jitter range", delay)
+		}
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	var attempts []int
+	err := Retry(context.Background(), testBackoff, func(attempt int) {
+		attempts = append(attempts, attempt)
+	}, func(ctx context.Context) error {
+		if len(attempts) < 3 {
+			return ErrServerUnreachable
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected success: %v", err)
+	}
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+		t.Errorf("unexpected attempts: %v", attempts)
+	}
+}
+
+func TestRetryStopsOnPermanentError(t *testing.T) {
+	attempts := 0
+	err := Retry(context.Background(), testBackoff, nil, func(ctx context.Context) error {
+		attempts++
+		return &APIError{StatusCode: http.StatusUnauthorized}
+	})
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected the unauthorized error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %v", attempts)
+	}
+}
+
+func TestRetryCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := Retry(ctx, testBackoff, nil, func(ctx context.Context) error {
+		attempts++
+		if attempts == 3 {
+			cancel()
+		}
+		return ErrServerUnreachable
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+	if !errors.Is(err, ErrServerUnreachable) {
+		t.Errorf("expected the last error to be wrapped, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %v", attempts)
+	}
+}
+
+func TestRetryDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	err := Retry(ctx, testBackoff, nil, func(ctx context.Context) error {
+		return &APIError{StatusCode: http.StatusServiceUnavailable, Retryable: true}
+	})
+
+	var apiErr *APIError
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &apiErr) {
+		t.Errorf("expected the deadline and the last API error, got %v", err)
+	}
+}
+
+func TestRetryWithoutAttempt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Retry(ctx, testBackoff, nil, func(ctx context.Context) error {
+		t.Error("the operation must not be called with an ended context")
+		return nil
+	})
+
+	if err != context.Canceled {
+		t.Errorf("expected the plain context error, got %v", err)
+	}
+}
@@ -19,6 +19,13 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CommNodeWorkerClient interface {
 	StartServer(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (CommNodeWorker_StartServerClient, error)
+	StopServer(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	// Replays all events of a server so far and then follows new events until the server is gone
+	WatchServer(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (CommNodeWorker_WatchServerClient, error)
+	ListServers(ctx context.Context, in *ListServersRequest, opts ...grpc.CallOption) (*ListServersResponse, error)
+	GetServer(ctx context.Context, in *GetServerRequest, opts ...grpc.CallOption) (*ServerStatus, error)
+	// Replaces the password of a running server with a new random password
+	RotatePassword(ctx context.Context, in *RotatePasswordRequest, opts ...grpc.CallOption) (*RotatePasswordResponse, error)
 }
 
 type commNodeWorkerClient struct {
@@ -61,11 +68,86 @@ func (x *commNodeWorkerStartServerClient) Recv() (*ServerEvent, error) {
 	return m, nil
 }
 
+func (c *commNodeWorkerClient) StopServer(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
+	err := c.cc.Invoke(ctx, "/CommNodeWorker/StopServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commNodeWorkerClient) WatchServer(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (CommNodeWorker_WatchServerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CommNodeWorker_ServiceDesc.Streams[1], "/CommNodeWorker/WatchServer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commNodeWorkerWatchServerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CommNodeWorker_WatchServerClient interface {
+	Recv() (*ServerEvent, error)
+	grpc.ClientStream
+}
+
+type commNodeWorkerWatchServerClient struct {
+	grpc.ClientStream
+}
+
+func (x *commNodeWorkerWatchServerClient) Recv() (*ServerEvent, error) {
+	m := new(ServerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *commNodeWorkerClient) ListServers(ctx context.Context, in *ListServersRequest, opts ...grpc.CallOption) (*ListServersResponse, error) {
+	out := new(ListServersResponse)
+	err := c.cc.Invoke(ctx, "/CommNodeWorker/ListServers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commNodeWorkerClient) GetServer(ctx context.Context, in *GetServerRequest, opts ...grpc.CallOption) (*ServerStatus, error) {
+	out := new(ServerStatus)
+	err := c.cc.Invoke(ctx, "/CommNodeWorker/GetServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commNodeWorkerClient) RotatePassword(ctx context.Context, in *RotatePasswordRequest, opts ...grpc.CallOption) (*RotatePasswordResponse, error) {
+	out := new(RotatePasswordResponse)
+	err := c.cc.Invoke(ctx, "/CommNodeWorker/RotatePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommNodeWorkerServer is the server API for CommNodeWorker service.
 // All implementations must embed UnimplementedCommNodeWorkerServer
 // for forward compatibility
 type CommNodeWorkerServer interface {
 	StartServer(*StartRequest, CommNodeWorker_StartServerServer) error
+	StopServer(context.Context, *StopRequest) (*StopResponse, error)
+	// Replays all events of a server so far and then follows new events until the server is gone
+	WatchServer(*WatchRequest, CommNodeWorker_WatchServerServer) error
+	ListServers(context.Context, *ListServersRequest) (*ListServersResponse, error)
+	GetServer(context.Context, *GetServerRequest) (*ServerStatus, error)
+	// Replaces the password of a running server with a new random password
+	RotatePassword(context.Context, *RotatePasswordRequest) (*RotatePasswordResponse, error)
 	mustEmbedUnimplementedCommNodeWorkerServer()
 }
 
@@ -76,6 +158,21 @@ type UnimplementedCommNodeWorkerServer struct {
 func (UnimplementedCommNodeWorkerServer) StartServer(*StartRequest, CommNodeWorker_StartServerServer) error {
 	return status.Errorf(codes.Unimplemented, "method StartServer not implemented")
 }
+func (UnimplementedCommNodeWorkerServer) StopServer(context.Context, *StopRequest) (*StopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopServer not implemented")
+}
+func (UnimplementedCommNodeWorkerServer) WatchServer(*WatchRequest, CommNodeWorker_WatchServerServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchServer not implemented")
+}
+func (UnimplementedCommNodeWorkerServer) ListServers(context.Context, *ListServersRequest) (*ListServersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServers not implemented")
+}
+func (UnimplementedCommNodeWorkerServer) GetServer(context.Context, *GetServerRequest) (*ServerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServer not implemented")
+}
+func (UnimplementedCommNodeWorkerServer) RotatePassword(context.Context, *RotatePasswordRequest) (*RotatePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotatePassword not implemented")
+}
 func (UnimplementedCommNodeWorkerServer) mustEmbedUnimplementedCommNodeWorkerServer() {}
 
 // UnsafeCommNodeWorkerServer may be embedded to opt out of forward compatibility for this service.
@@ -110,19 +207,134 @@ func (x *commNodeWorkerStartServerServer) Send(m *ServerEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CommNodeWorker_StopServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommNodeWorkerServer).StopServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CommNodeWorker/StopServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommNodeWorkerServer).StopServer(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommNodeWorker_WatchServer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommNodeWorkerServer).WatchServer(m, &commNodeWorkerWatchServerServer{stream})
+}
+
+type CommNodeWorker_WatchServerServer interface {
+	Send(*ServerEvent) error
+	grpc.ServerStream
+}
+
+type commNodeWorkerWatchServerServer struct {
+	grpc.ServerStream
+}
+
+func (x *commNodeWorkerWatchServerServer) Send(m *ServerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CommNodeWorker_ListServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommNodeWorkerServer).ListServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CommNodeWorker/ListServers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommNodeWorkerServer).ListServers(ctx, req.(*ListServersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommNodeWorker_GetServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommNodeWorkerServer).GetServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CommNodeWorker/GetServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommNodeWorkerServer).GetServer(ctx, req.(*GetServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommNodeWorker_RotatePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotatePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommNodeWorkerServer).RotatePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CommNodeWorker/RotatePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommNodeWorkerServer).RotatePassword(ctx, req.(*RotatePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommNodeWorker_ServiceDesc is the grpc.ServiceDesc for CommNodeWorker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var CommNodeWorker_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "CommNodeWorker",
 	HandlerType: (*CommNodeWorkerServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StopServer",
+			Handler:    _CommNodeWorker_StopServer_Handler,
+		},
+		{
+			MethodName: "ListServers",
+			Handler:    _CommNodeWorker_ListServers_Handler,
+		},
+		{
+			MethodName: "GetServer",
+			Handler:    _CommNodeWorker_GetServer_Handler,
+		},
+		{
+			MethodName: "RotatePassword",
+			Handler:    _CommNodeWorker_RotatePassword_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "StartServer",
 			Handler:       _CommNodeWorker_StartServer_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchServer",
+			Handler:       _CommNodeWorker_WatchServer_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "grpc/worker.proto",
 }
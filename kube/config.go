@@ -0,0 +1,36 @@
+package kube
+
+import (
+	"errors"
+)
+
+// Config contains the settings for running servers as Pods in a Kubernetes cluster
+type Config struct {
+	// Path of a kubeconfig file. The in-cluster configuration is used if empty.
+	Kubeconfig string `yaml:"kubeconfig"`
+
+	// Namespace in which the server Pods are created
+	Namespace string `yaml:"namespace"`
+
+	// Node on which the server Pods run. The worker reaches the server APIs through the host ports of this node so
+	// it has to run there with host networking.
+	NodeName string `yaml:"node_name"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Namespace: "default",
+	}
+}
+
+// Validate checks if the settings are usable
+func (c Config) Validate() error {
+	if c.Namespace == "" {
+		return errors.New("namespace must not be empty")
+	}
+	if c.NodeName == "" {
+		return errors.New("node name must not be empty")
+	}
+
+	return nil
+}
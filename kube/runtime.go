@@ -0,0 +1,540 @@
+// Package kube implements a container runtime which runs every server as a Pod in a Kubernetes cluster
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// Name of the server container inside the Pod
+	containerName = "server"
+
+	// The standalone image expects the game data here
+	dataMountPath = "/fso"
+	dataVolume    = "fso-data"
+
+	// The server keeps its preferences below this directory. Its configuration is mounted from a Secret with the same
+	// name as the Pod.
+	prefPath        = "/commnode"
+	configVolume    = "server-config"
+	configSecretKey = "multi.cfg"
+
+	// How often the state of a Pod is checked while waiting for it
+	podPollInterval = time.Second * 2
+)
+
+var errStatsUnsupported = errors.New("resource usage is not available for server Pods")
+
+// Reasons of a waiting server container for which the node has given up pulling the image. The first failed pull
+// is only reported as ErrImagePull which is followed by ImagePullBackOff if it does not succeed on its own.
+var pullFailureReasons = map[string]bool{
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// Runtime runs the servers as Pods. The host ports of the Pods are bound on the configured node, so the servers are
+// reachable the same way as on a Docker host.
+type Runtime struct {
+	clientset kubernetes.Interface
+	config    Config
+
+	// Pods which have been created but not started yet. Kubernetes starts Pods right away so they are only submitted
+	// on start.
+	pendingMutex sync.Mutex
+	pending      map[string]*pendingPod
+}
+
+type pendingPod struct {
+	pod    *corev1.Pod
+	secret *corev1.Secret
+}
+
+func NewRuntime(clientset kubernetes.Interface, config Config) *Runtime {
+	return &Runtime{
+		clientset: clientset,
+		config:    config,
+		pending:   make(map[string]*pendingPod),
+	}
+}
+
+// NewClientset connects to the cluster described by the kubeconfig file or to the cluster the worker runs in
+func NewClientset(config Config) (kubernetes.Interface, error) {
+	var restConfig *rest.Config
+	var err error
+	if config.Kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", config.Kubeconfig)
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// convertError maps Kubernetes errors to the errors of the containers package
+func convertError(err error) error {
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: %v", containers.ErrNotFound, err)
+	}
+
+	return err
+}
+
+func (r *Runtime) pods() typedcorev1.PodInterface {
+	return r.clientset.CoreV1().Pods(r.config.Namespace)
+}
+
+func (r *Runtime) secrets() typedcorev1.SecretInterface {
+	return r.clientset.CoreV1().Secrets(r.config.Namespace)
+}
+
+// deleteSecret removes the configuration of a server. It is only needed until the server has read it.
+func (r *Runtime) deleteSecret(ctx context.Context, id string) error {
+	err := r.secrets().Delete(ctx, id, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// takePending removes a Pod which was not started yet. Returns nil if the Pod was already started.
+func (r *Runtime) takePending(id string) *pendingPod {
+	r.pendingMutex.Lock()
+	defer r.pendingMutex.Unlock()
+
+	pod := r.pending[id]
+	delete(r.pending, id)
+
+	return pod
+}
+
+func (r *Runtime) isPending(id string) bool {
+	r.pendingMutex.Lock()
+	defer r.pendingMutex.Unlock()
+
+	_, ok := r.pending[id]
+	return ok
+}
+
+// Pull does nothing since the image is pulled by the node when the Pod starts. Start reports the progress of that pull.
+func (r *Runtime) Pull(ctx context.Context, image string, progressCb containers.PullProgressCallback) error {
+	return progressCb(containers.PullProgress{LayerId: image, Status: "Pulled by the node"})
+}
+
+// splitLabels puts labels with values Kubernetes does not accept as label values into annotations
+func splitLabels(labels map[string]string) (map[string]string, map[string]string) {
+	podLabels := make(map[string]string)
+	annotations := make(map[string]string)
+
+	for key, value := range labels {
+		if len(validation.IsValidLabelValue(value)) == 0 {
+			podLabels[key] = value
+		} else {
+			annotations[key] = value
+		}
+	}
+
+	return podLabels, annotations
+}
+
+func (r *Runtime) Create(ctx context.Context, spec containers.Spec) (string, error) {
+	podLabels, annotations := splitLabels(spec.Labels)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   spec.Name,
+			Labels: podLabels,
+		},
+		Data: map[string][]byte{
+			configSecretKey: containers.ServerConfig(spec),
+		},
+	}
+
+	gracePeriod := int64(spec.StopTimeout / time.Second)
+	hostPathType := corev1.HostPathDirectory
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        spec.Name,
+			Labels:      podLabels,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			NodeName:                      r.config.NodeName,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: &gracePeriod,
+			Containers: []corev1.Container{
+				{
+					Name:  containerName,
+					Image: spec.Image,
+					Args:  spec.Args,
+					Env: []corev1.EnvVar{
+						{Name: containers.PrefPathEnv, Value: prefPath},
+					},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "api",
+							Protocol:      corev1.ProtocolTCP,
+							ContainerPort: int32(spec.ApiPort),
+							HostPort:      int32(spec.ApiPort),
+							HostIP:        "127.0.0.1",
+						},
+						{
+							Name:          "game",
+							Protocol:      corev1.ProtocolUDP,
+							ContainerPort: int32(spec.UdpPort),
+							HostPort:      int32(spec.UdpPort),
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      dataVolume,
+							MountPath: dataMountPath,
+						},
+						{
+							Name:      configVolume,
+							MountPath: path.Join(prefPath, containers.ServerConfigPath),
+							SubPath:   configSecretKey,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: dataVolume,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: spec.DataPath,
+							Type: &hostPathType,
+						},
+					},
+				},
+				{
+					Name: configVolume,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: secret.Name},
+					},
+				},
+			},
+		},
+	}
+
+	r.pendingMutex.Lock()
+	r.pending[pod.Name] = &pendingPod{pod: pod, secret: secret}
+	r.pendingMutex.Unlock()
+
+	return pod.Name, nil
+}
+
+// watchPod watches the Pod with the specified name. It may not exist yet.
+func (r *Runtime) watchPod(ctx context.Context, id string, resourceVersion string) (watch.Interface, error) {
+	return r.pods().Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", id).String(),
+		ResourceVersion: resourceVersion,
+	})
+}
+
+// Start submits the Pod and waits until the node has pulled the image and runs the server
+func (r *Runtime) Start(ctx context.Context, id string, progressCb containers.PullProgressCallback) error {
+	pending := r.takePending(id)
+	if pending == nil {
+		return fmt.Errorf("%w: %v", containers.ErrNotFound, id)
+	}
+
+	// Started before the Pod is created so that no status change can be missed
+	watcher, err := r.watchPod(ctx, id, "")
+	if err != nil {
+		return fmt.Errorf("failed to watch Pod: %w", err)
+	}
+	defer func() {
+		// The watcher is replaced whenever the API server ends the watch
+		watcher.Stop()
+	}()
+
+	secret, err := r.secrets().Create(ctx, pending.secret, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create server configuration: %w", err)
+	}
+
+	pod, err := r.pods().Create(ctx, pending.pod, metav1.CreateOptions{})
+	if err != nil {
+		if deleteErr := r.deleteSecret(ctx, id); deleteErr != nil {
+			log.Printf("Failed to delete configuration of Pod %v: %v", id, deleteErr)
+		}
+		return err
+	}
+
+	// Lets Kubernetes delete the configuration together with the Pod even if the worker is gone by then
+	secret.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "v1", Kind: "Pod", Name: pod.Name, UID: pod.UID},
+	}
+	if _, err := r.secrets().Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		log.Printf("Failed to link configuration of Pod %v to the Pod: %v", id, err)
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// The API server ends watches after a while so continue where the old one stopped
+				watcher, err = r.watchPod(ctx, id, pod.ResourceVersion)
+				if err != nil {
+					return fmt.Errorf("failed to watch Pod: %w", err)
+				}
+				continue
+			}
+
+			switch event.Type {
+			case watch.Error:
+				return fmt.Errorf("failed to watch Pod: %w", apierrors.FromObject(event.Object))
+			case watch.Deleted:
+				return fmt.Errorf("%w: Pod %v was deleted while starting", containers.ErrNotFound, id)
+			}
+
+			updated, ok := event.Object.(*corev1.Pod)
+			if !ok || updated.Name != id {
+				continue
+			}
+			pod = updated
+
+			running, err := checkStarting(pod, progressCb)
+			if err != nil || running {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// checkStarting returns true once the server container of a starting Pod runs. Until then the reason why it is
+// still waiting is reported as pull progress.
+func checkStarting(pod *corev1.Pod, progressCb containers.PullProgressCallback) (bool, error) {
+	if code, exited := exitCode(pod); exited {
+		return false, fmt.Errorf("server exited with code %v while starting", code)
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != containerName {
+			continue
+		}
+		if status.State.Running != nil {
+			return true, nil
+		}
+
+		waiting := status.State.Waiting
+		if waiting == nil || waiting.Reason == "" {
+			break
+		}
+		if pullFailureReasons[waiting.Reason] {
+			return false, fmt.Errorf("%w: %v: %v", containers.ErrImagePull, waiting.Reason, waiting.Message)
+		}
+
+		progress := waiting.Reason
+		if waiting.Message != "" {
+			progress += ": " + waiting.Message
+		}
+		return false, progressCb(containers.PullProgress{LayerId: status.Image, Status: progress})
+	}
+
+	if pod.Status.Phase == "" {
+		// Not scheduled yet
+		return false, nil
+	}
+	return false, progressCb(containers.PullProgress{LayerId: pod.Spec.Containers[0].Image, Status: string(pod.Status.Phase)})
+}
+
+// exitCode returns the exit code of the server container if it has terminated
+func exitCode(pod *corev1.Pod) (int64, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName && status.State.Terminated != nil {
+			return int64(status.State.Terminated.ExitCode), true
+		}
+	}
+
+	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		return -1, true
+	}
+
+	return 0, false
+}
+
+// Wait polls the Pod until its server has exited. Pods are deleted afterwards since Kubernetes does not remove them
+// on its own.
+func (r *Runtime) Wait(ctx context.Context, id string) (int64, error) {
+	if r.isPending(id) {
+		return -1, nil
+	}
+
+	for first := true; ; first = false {
+		pod, err := r.pods().Get(ctx, id, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) && !first {
+			// Deleted while we were waiting so the exit code is lost
+			return -1, nil
+		}
+		if err != nil {
+			return -1, convertError(err)
+		}
+
+		if code, exited := exitCode(pod); exited {
+			if err := r.Remove(ctx, id); err != nil && !errors.Is(err, containers.ErrNotFound) {
+				return code, err
+			}
+			return code, nil
+		}
+
+		select {
+		case <-time.After(podPollInterval):
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		}
+	}
+}
+
+// Stop deletes the Pod which gives the server its termination grace period to exit
+func (r *Runtime) Stop(ctx context.Context, id string) error {
+	if r.takePending(id) != nil {
+		return nil
+	}
+
+	if err := r.pods().Delete(ctx, id, metav1.DeleteOptions{}); err != nil {
+		return convertError(err)
+	}
+
+	return r.deleteSecret(ctx, id)
+}
+
+func (r *Runtime) Remove(ctx context.Context, id string) error {
+	if r.takePending(id) != nil {
+		return nil
+	}
+
+	gracePeriod := int64(0)
+	err := r.pods().Delete(ctx, id, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+
+	// The configuration is removed even if the Pod is gone already
+	if secretErr := r.deleteSecret(ctx, id); secretErr != nil && err == nil {
+		err = secretErr
+	}
+
+	return convertError(err)
+}
+
+func (r *Runtime) WaitForRemoval(ctx context.Context, id string) error {
+	for {
+		_, err := r.pods().Get(ctx, id, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(podPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *Runtime) Logs(ctx context.Context, id string) (io.ReadCloser, error) {
+	logs, err := r.pods().GetLogs(id, &corev1.PodLogOptions{Container: containerName}).Stream(ctx)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return logs, nil
+}
+
+func (r *Runtime) Stats(ctx context.Context, id string) (containers.Stats, error) {
+	if _, err := r.pods().Get(ctx, id, metav1.GetOptions{}); err != nil {
+		return containers.Stats{}, convertError(err)
+	}
+
+	// This would need the metrics API which is not available in every cluster
+	return containers.Stats{}, errStatsUnsupported
+}
+
+func (r *Runtime) Inspect(ctx context.Context, id string) (containers.Info, error) {
+	pod, err := r.pods().Get(ctx, id, metav1.GetOptions{})
+	if err != nil {
+		return containers.Info{}, convertError(err)
+	}
+
+	info := containers.Info{
+		Id:     pod.Name,
+		Labels: make(map[string]string),
+	}
+	for key, value := range pod.Labels {
+		info.Labels[key] = value
+	}
+	for key, value := range pod.Annotations {
+		info.Labels[key] = value
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name != containerName {
+			continue
+		}
+
+		info.Image = container.Image
+		for _, port := range container.Ports {
+			switch port.Protocol {
+			case corev1.ProtocolTCP:
+				info.ApiPort = uint16(port.HostPort)
+			case corev1.ProtocolUDP:
+				info.UdpPort = uint16(port.HostPort)
+			}
+		}
+	}
+
+	secret, err := r.secrets().Get(ctx, id, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("Failed to read configuration of Pod %v: %v", id, err)
+	} else {
+		info.ApiUsername, info.ApiPassword = containers.ParseServerConfig(secret.Data[configSecretKey])
+	}
+
+	return info, nil
+}
+
+func (r *Runtime) List(ctx context.Context, label string) ([]string, error) {
+	pods, err := r.pods().List(ctx, metav1.ListOptions{LabelSelector: label})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			ids = append(ids, pod.Name)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,410 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var testConfig = Config{Namespace: "games", NodeName: "node-1"}
+
+var testMetadata = containers.ServerMetadata{
+	ServerId:   "0123456789abcdef",
+	Name:       "Friday Night Coop!",
+	Owner:      "Alpha 1",
+	PortOffset: 2,
+	Created:    time.Date(2026, 10, 16, 20, 30, 0, 0, time.UTC),
+}
+
+var runningStatus = corev1.PodStatus{
+	Phase: corev1.PodRunning,
+	ContainerStatuses: []corev1.ContainerStatus{
+		{
+			Name:  containerName,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		},
+	},
+}
+
+// newTestRuntime returns a runtime whose Pods run as soon as they are created
+func newTestRuntime() (*Runtime, *fake.Clientset) {
+	return newTestRuntimeWithStatus(runningStatus)
+}
+
+// newTestRuntimeWithStatus returns a runtime whose Pods get the status right away like the kubelet would set it
+func newTestRuntimeWithStatus(status corev1.PodStatus) (*Runtime, *fake.Clientset) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		// Pods created by the tests themselves keep their status
+		if pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod); pod.Status.Phase == "" {
+			pod.Status = status
+		}
+		// Lets the fake store the Pod
+		return false, nil, nil
+	})
+
+	return NewRuntime(clientset, testConfig), clientset
+}
+
+func waitingStatus(reason string, message string) corev1.PodStatus {
+	return corev1.PodStatus{
+		Phase: corev1.PodPending,
+		ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:  containerName,
+				Image: "image",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}},
+			},
+		},
+	}
+}
+
+// startServer starts a server container the same way the worker does and returns the ID of its Pod
+func startServer(t *testing.T, runtime *Runtime) string {
+	serverContainer := containers.NewServerContainer(runtime, containers.Config{
+		InstanceId:  "worker-1",
+		Image:       "scpfs2open/fso-standalone:release",
+		DataPath:    "/data/fso/fs2",
+		BaseApiPort: 8080,
+		BaseUdpPort: 7808,
+		StopTimeout: time.Second * 5,
+	}, testMetadata, "user", "secret")
+
+	err := serverContainer.Start(context.Background(), func(event containers.ContainerEvent) error { return nil })
+	if err != nil {
+		t.Fatalf("failed to start the server: %v", err)
+	}
+
+	return serverContainer.ContainerId()
+}
+
+// setPodStatus changes the status of a Pod like the kubelet would
+func setPodStatus(t *testing.T, clientset *fake.Clientset, id string, status corev1.PodStatus) {
+	pods := clientset.CoreV1().Pods(testConfig.Namespace)
+
+	pod, err := pods.Get(context.Background(), id, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod.Status = status
+	if _, err := pods.UpdateStatus(context.Background(), pod, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertDeleted(t *testing.T, clientset *fake.Clientset, id string) {
+	ctx := context.Background()
+
+	if _, err := clientset.CoreV1().Pods(testConfig.Namespace).Get(ctx, id, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the Pod to be deleted, got %v", err)
+	}
+	if _, err := clientset.CoreV1().Secrets(testConfig.Namespace).Get(ctx, id, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the configuration to be deleted, got %v", err)
+	}
+}
+
+func TestCreateAndStart(t *testing.T) {
+	runtime, clientset := newTestRuntime()
+	ctx := context.Background()
+
+	id, err := runtime.Create(ctx, containers.Spec{Name: "fso-pending", Image: "image"})
+	if err != nil {
+		t.Fatalf("failed to create the Pod: %v", err)
+	}
+	if _, err := clientset.CoreV1().Pods(testConfig.Namespace).Get(ctx, id, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("the Pod must not be submitted before it is started, got %v", err)
+	}
+
+	id = startServer(t, runtime)
+	if id != "fso-0123456789abcdef" {
+		t.Errorf("unexpected Pod name %v", id)
+	}
+
+	pod, err := clientset.CoreV1().Pods(testConfig.Namespace).Get(ctx, id, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("the Pod was not created: %v", err)
+	}
+
+	if pod.Spec.NodeName != "node-1" || pod.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("unexpected Pod spec: %+v", pod.Spec)
+	}
+	if *pod.Spec.TerminationGracePeriodSeconds != 5 {
+		t.Errorf("expected the stop timeout as grace period, got %v", *pod.Spec.TerminationGracePeriodSeconds)
+	}
+
+	// Values which are no valid label values end up in the annotations
+	for key, value := range map[string]string{"fso_server": "", "fso_server.id": "0123456789abcdef", "fso_server.port_offset": "2"} {
+		if actual, ok := pod.Labels[key]; !ok || actual != value {
+			t.Errorf("expected label %v=%q, got %q", key, value, actual)
+		}
+	}
+	for key, value := range map[string]string{"fso_server.name": "Friday Night Coop!", "fso_server.owner": "Alpha 1", "fso_server.image": "scpfs2open/fso-standalone:release", "fso_server.created": "2026-10-16T20:30:00Z"} {
+		if actual, ok := pod.Annotations[key]; !ok || actual != value {
+			t.Errorf("expected annotation %v=%q, got %q", key, value, actual)
+		}
+		if _, ok := pod.Labels[key]; ok {
+			t.Errorf("%v must not be a label", key)
+		}
+	}
+
+	container := pod.Spec.Containers[0]
+	if container.Image != "scpfs2open/fso-standalone:release" || len(container.Args) != 2 || container.Args[1] != "7810" {
+		t.Errorf("unexpected container: %+v", container)
+	}
+	ports := map[corev1.Protocol]corev1.ContainerPort{}
+	for _, port := range container.Ports {
+		ports[port.Protocol] = port
+	}
+	if api := ports[corev1.ProtocolTCP]; api.HostPort != 8082 || api.ContainerPort != 8082 || api.HostIP != "127.0.0.1" {
+		t.Errorf("unexpected API port: %+v", api)
+	}
+	if game := ports[corev1.ProtocolUDP]; game.HostPort != 7810 || game.ContainerPort != 7810 || game.HostIP != "" {
+		t.Errorf("unexpected game port: %+v", game)
+	}
+
+	secret, err := clientset.CoreV1().Secrets(testConfig.Namespace).Get(ctx, id, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("the configuration was not created: %v", err)
+	}
+	if username, password := containers.ParseServerConfig(secret.Data[configSecretKey]); username != "user" || password != "secret" {
+		t.Errorf("unexpected configuration: %s", secret.Data[configSecretKey])
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Name != id {
+		t.Errorf("expected the configuration to be owned by the Pod: %+v", secret.OwnerReferences)
+	}
+}
+
+func TestStartUnknown(t *testing.T) {
+	runtime, _ := newTestRuntime()
+
+	if err := runtime.Start(context.Background(), "fso-unknown", nil); !errors.Is(err, containers.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a Pod which was never created, got %v", err)
+	}
+}
+
+func TestStartReportsPull(t *testing.T) {
+	runtime, clientset := newTestRuntimeWithStatus(waitingStatus("ErrImagePull", "registry unavailable"))
+	ctx := context.Background()
+
+	id, err := runtime.Create(ctx, containers.Spec{Name: "fso-pulling", Image: "image"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []containers.PullProgress
+	err = runtime.Start(ctx, id, func(p containers.PullProgress) error {
+		progress = append(progress, p)
+		// The next attempt of the node succeeds
+		setPodStatus(t, clientset, id, runningStatus)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected the start to wait for the running server, got %v", err)
+	}
+
+	if len(progress) != 1 || progress[0].LayerId != "image" || progress[0].Status != "ErrImagePull: registry unavailable" {
+		t.Errorf("expected the failed pull to be reported as progress, got %+v", progress)
+	}
+}
+
+func TestStartPullFailure(t *testing.T) {
+	runtime, _ := newTestRuntimeWithStatus(waitingStatus("ImagePullBackOff", "Back-off pulling image"))
+	ctx := context.Background()
+
+	id, err := runtime.Create(ctx, containers.Spec{Name: "fso-backoff", Image: "image"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = runtime.Start(ctx, id, func(containers.PullProgress) error { return nil })
+	if !errors.Is(err, containers.ErrImagePull) {
+		t.Errorf("expected ErrImagePull, got %v", err)
+	}
+}
+
+func TestStartCanceled(t *testing.T) {
+	runtime, _ := newTestRuntimeWithStatus(corev1.PodStatus{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	id, err := runtime.Create(ctx, containers.Spec{Name: "fso-unscheduled", Image: "image"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runtime.Start(ctx, id, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the start to wait for the Pod until canceled, got %v", err)
+	}
+}
+
+func TestInspectRestoresServer(t *testing.T) {
+	runtime, clientset := newTestRuntime()
+	id := startServer(t, runtime)
+	setPodStatus(t, clientset, id, corev1.PodStatus{Phase: corev1.PodRunning})
+
+	info, err := runtime.Inspect(context.Background(), id)
+	if err != nil {
+		t.Fatalf("failed to inspect the Pod: %v", err)
+	}
+	if info.ApiPort != 8082 || info.UdpPort != 7810 || info.ApiUsername != "user" || info.ApiPassword != "secret" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	serverContainers, err := containers.FindServerContainers(context.Background(), runtime, containers.Config{InstanceId: "worker-1"})
+	if err != nil {
+		t.Fatalf("failed to find the server: %v", err)
+	}
+	if len(serverContainers) != 1 {
+		t.Fatalf("expected one server, got %v", len(serverContainers))
+	}
+
+	serverContainer := serverContainers[0]
+	if metadata := serverContainer.Metadata(); metadata != testMetadata {
+		t.Errorf("expected the metadata to survive the labels and annotations, got %+v", metadata)
+	}
+	if username, password := serverContainer.ApiCredentials(); username != "user" || password != "secret" {
+		t.Errorf("unexpected credentials %v:%v", username, password)
+	}
+	if serverContainer.ApiPort != 8082 || serverContainer.UdpPort != 7810 {
+		t.Errorf("unexpected ports %v and %v", serverContainer.ApiPort, serverContainer.UdpPort)
+	}
+}
+
+func TestInspectMissing(t *testing.T) {
+	runtime, _ := newTestRuntime()
+
+	if _, err := runtime.Inspect(context.Background(), "fso-missing"); !errors.Is(err, containers.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWaitForTerminatedContainer(t *testing.T) {
+	runtime, clientset := newTestRuntime()
+	id := startServer(t, runtime)
+	setPodStatus(t, clientset, id, corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:  containerName,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 5}},
+			},
+		},
+	})
+
+	exitCode, err := runtime.Wait(context.Background(), id)
+	if err != nil {
+		t.Fatalf("failed to wait for the Pod: %v", err)
+	}
+	if exitCode != 5 {
+		t.Errorf("expected exit code 5, got %v", exitCode)
+	}
+
+	// Kubernetes does not remove exited Pods on its own
+	assertDeleted(t, clientset, id)
+}
+
+func TestWaitForFailedPod(t *testing.T) {
+	runtime, clientset := newTestRuntime()
+	id := startServer(t, runtime)
+	setPodStatus(t, clientset, id, corev1.PodStatus{Phase: corev1.PodFailed})
+
+	exitCode, err := runtime.Wait(context.Background(), id)
+	if err != nil || exitCode != -1 {
+		t.Errorf("expected exit code -1 without a container status, got %v %v", exitCode, err)
+	}
+}
+
+func TestWaitCanceled(t *testing.T) {
+	runtime, clientset := newTestRuntime()
+	id := startServer(t, runtime)
+	setPodStatus(t, clientset, id, corev1.PodStatus{Phase: corev1.PodRunning})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	if _, err := runtime.Wait(ctx, id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+}
+
+func TestStopAndRemove(t *testing.T) {
+	runtime, clientset := newTestRuntime()
+	ctx := context.Background()
+
+	id := startServer(t, runtime)
+	if err := runtime.Stop(ctx, id); err != nil {
+		t.Fatalf("failed to stop the Pod: %v", err)
+	}
+	assertDeleted(t, clientset, id)
+
+	if err := runtime.Remove(ctx, id); !errors.Is(err, containers.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a deleted Pod, got %v", err)
+	}
+	if err := runtime.WaitForRemoval(ctx, id); err != nil {
+		t.Errorf("expected a deleted Pod to count as removed, got %v", err)
+	}
+
+	pendingId, err := runtime.Create(ctx, containers.Spec{Name: "fso-pending", Image: "image"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runtime.Remove(ctx, pendingId); err != nil {
+		t.Errorf("failed to remove a Pod which was not started: %v", err)
+	}
+	if err := runtime.Start(ctx, pendingId, nil); !errors.Is(err, containers.ErrNotFound) {
+		t.Errorf("a removed Pod must not be started, got %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	runtime, clientset := newTestRuntime()
+	pods := clientset.CoreV1().Pods(testConfig.Namespace)
+	ctx := context.Background()
+
+	running := startServer(t, runtime)
+	setPodStatus(t, clientset, running, corev1.PodStatus{Phase: corev1.PodRunning})
+
+	deletedAt := metav1.Now()
+	for _, pod := range []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "fso-pending", Labels: map[string]string{"fso_server": ""}},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "fso-terminating", Labels: map[string]string{"fso_server": ""}, DeletionTimestamp: &deletedAt},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	} {
+		if _, err := pods.Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids, err := runtime.List(ctx, "fso_server")
+	if err != nil {
+		t.Fatalf("failed to list the Pods: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != running {
+		t.Errorf("expected only %v, got %v", running, ids)
+	}
+
+	// Pods of other namespaces are not ours
+	other := NewRuntime(clientset, Config{Namespace: "other", NodeName: "node-1"})
+	if ids, err := other.List(ctx, "fso_server"); err != nil || len(ids) != 0 {
+		t.Errorf("expected no Pods in another namespace, got %v %v", ids, err)
+	}
+}
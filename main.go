@@ -2,17 +2,31 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/scp-fs2open/CommnodeWorker/config"
+	"github.com/scp-fs2open/CommnodeWorker/containers"
 	"github.com/scp-fs2open/CommnodeWorker/docker"
+	"github.com/scp-fs2open/CommnodeWorker/kube"
+	"github.com/scp-fs2open/CommnodeWorker/process"
 	"github.com/scp-fs2open/CommnodeWorker/servers"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
 	pb "github.com/scp-fs2open/CommnodeWorker/grpc"
@@ -21,15 +35,82 @@ import (
 )
 
 const (
-	grpcPort = ":50051"
+	// How long we wait for a server to report its players when querying its status
+	playerQueryTimeout = time.Second * 5
+
+	// Prepended to the requested name of every server
+	serverNamePrefix = "CommNode server "
+
+	// Limits of the standalone server settings
+	maxPasswordLength = 16
+	maxFrameCap       = 240
 )
 
 type workerServer struct {
 	pb.UnimplementedCommNodeWorkerServer
 
-	dockerClient client.APIClient
+	runtime containers.Runtime
+
+	// Used for reaching the server APIs if they are not on this host
+	dialApi fsoApi.DialFunc
 
 	serverManager *servers.ServerManager
+
+	// Arguments the configuration was loaded from so that it can be reloaded later
+	configName string
+	configArgs []string
+
+	configMutex sync.RWMutex
+	config      *config.Config
+}
+
+func (s *workerServer) currentConfig() *config.Config {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return s.config
+}
+
+// reloadConfig reads the configuration again and applies all settings which can be changed at runtime. Running
+// servers only pick up whether they are stopped on shutdown.
+func (s *workerServer) reloadConfig() {
+	updated, err := config.Load(s.configName, s.configArgs)
+	if err != nil {
+		log.Printf("Failed to reload configuration: %v", err)
+		return
+	}
+
+	s.configMutex.Lock()
+	reloaded, applied, ignored, err := config.Reload(s.config, updated)
+	if err != nil {
+		s.configMutex.Unlock()
+		log.Printf("Rejected reloaded configuration: %v", err)
+		return
+	}
+	s.config = reloaded
+	s.configMutex.Unlock()
+
+	s.serverManager.SetConfig(reloaded.Servers)
+
+	if len(applied) == 0 && len(ignored) == 0 {
+		log.Printf("Reloaded configuration without changes")
+	}
+	for _, change := range applied {
+		log.Printf("Configuration changed: %v", change)
+	}
+	for _, change := range ignored {
+		log.Printf("Configuration change requires a restart and was ignored: %v", change)
+	}
+}
+
+func installReloadHandler(handler func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	go func() {
+		for range c {
+			handler()
+		}
+	}()
 }
 
 func installInterruptHandler(handler func()) {
@@ -42,96 +123,439 @@ func installInterruptHandler(handler func()) {
 	}()
 }
 
-func (s *workerServer) StartServer(in *pb.StartRequest, stream pb.CommNodeWorker_StartServerServer) (err error) {
-	log.Printf("Starting server with name: %v", in.GetName())
+func readyEvent(cfg *config.Config, server *servers.Server, serverContainer *containers.ServerContainer, serverName string) *pb.ServerEvent {
+	address := net.JoinHostPort(cfg.PublicHost, strconv.FormatUint(uint64(serverContainer.UdpPort), 10))
+
+	return &pb.ServerEvent{
+		Type:    pb.ServerEvent_ServerReady,
+		Message: serverName,
+		Payload: &pb.ServerEvent_Ready{Ready: &pb.ServerReadyPayload{
+			Host:            cfg.PublicHost,
+			UdpPort:         uint32(serverContainer.UdpPort),
+			ServerId:        server.Id,
+			ConnectArgument: "-connect " + address,
+		}},
+	}
+}
+
+// startServer runs the startup of a server in the background. It is independent of the client which requested the
+// server so a disconnecting client does not abort the startup.
+func (s *workerServer) startServer(in *pb.StartRequest, password string, server *servers.Server, events *eventPublisher) (err error) {
+	ctx := server.StartContext()
+	cfg := s.currentConfig()
 
-	// We need this quite early so do this first
-	server := s.serverManager.CreateServer()
+	// Every step below registers how it can be undone so that a failed start does not leak anything
+	var undo rollback
+	undo.add("server registration", func(ctx context.Context) error {
+		server.Release()
+		return nil
+	})
+
+	// Keeps track of what we are currently doing so that we can report where we failed
+	phase := pb.FailedPayload_ServerSetup
 	defer func() {
-		// If we error out of here we need to free the port again
 		if err != nil {
-			server.FreePort()
+			startErr := newStartError(phase, err)
+			err = startErr
+			log.Printf("Starting server %v failed: %v", server.Id, startErr)
+
+			// This has to be published before the rollback since releasing the server closes its event log
+			events.publish(startErr.failedEvent())
+
+			// The port may only be freed after the container is gone so this needs to happen in order
+			undo.run()
 		}
 	}()
 
-	imageName := "scpfs2open/fso-standalone:release"
-	serverContainer := docker.NewServerContainer(s.dockerClient, imageName, uint16(server.PortOffset))
+	// Every server gets its own admin credentials so that nobody else on the host can control it
+	var apiCredentials fsoApi.Credentials
+	apiCredentials.Username, err = generatePassword(apiUsernameLength)
+	if err != nil {
+		return
+	}
+	apiCredentials.Password, err = generatePassword(apiPasswordLength)
+	if err != nil {
+		return
+	}
 
-	err = serverContainer.Start(stream.Context(), func(progressState uint32, message string) error {
-		eventType := pb.ServerEvent_Invalid
-		switch progressState {
-		case docker.ProgressPulling:
-			eventType = pb.ServerEvent_ContainerImagePull
-		case docker.ProgressStarting:
-			eventType = pb.ServerEvent_ContainerStart
+	imageName := cfg.Containers.Image
+	metadata := containers.ServerMetadata{
+		ServerId:   server.Id,
+		Name:       server.Name,
+		Owner:      server.Owner,
+		PortOffset: uint16(server.PortOffset),
+		Created:    server.StartTime,
+	}
+	serverContainer := containers.NewServerContainer(s.runtime, cfg.Containers, metadata,
+		apiCredentials.Username, apiCredentials.Password)
+	server.AttachContainer(serverContainer)
+	undo.add("server container", serverContainer.RemoveContainer)
+
+	phase = pb.FailedPayload_ImagePull
+	err = serverContainer.Start(ctx, func(progress containers.ContainerEvent) error {
+		event := &pb.ServerEvent{Message: progress.Message}
+		switch progress.State {
+		case containers.ProgressPulling:
+			event.Type = pb.ServerEvent_ContainerImagePull
+			if progress.Pull != nil {
+				event.Payload = &pb.ServerEvent_ImagePull{ImagePull: &pb.ImagePullPayload{
+					Image:   imageName,
+					LayerId: progress.Pull.LayerId,
+					Status:  progress.Pull.Status,
+					Current: progress.Pull.Current,
+					Total:   progress.Pull.Total,
+				}}
+			}
+		case containers.ProgressStarting:
+			phase = pb.FailedPayload_ContainerStart
+			event.Type = pb.ServerEvent_ContainerStart
+		case containers.ProgressStarted:
+			event.Type = pb.ServerEvent_ContainerStarted
+			event.Payload = &pb.ServerEvent_Container{Container: &pb.ContainerStartedPayload{
+				ContainerId: progress.ContainerId,
+				Image:       imageName,
+			}}
 		}
 
-		err := stream.Send(&pb.ServerEvent{Type: eventType, Message: message})
-		if err != nil {
-			return err
+		events.publish(event)
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, containers.ErrImagePull) {
+			// Some runtimes only pull the image while starting the container
+			phase = pb.FailedPayload_ImagePull
 		}
+		return
+	}
+
+	phase = pb.FailedPayload_WaitForOnline
+	events.publish(&pb.ServerEvent{Type: pb.ServerEvent_SettingUpServer, Message: imageName})
 
+	fsoClient := fsoApi.NewClientWithDialer(serverContainer.ApiPort, apiCredentials, s.dialApi)
+	undo.add("server API client", func(ctx context.Context) error {
+		fsoClient.Close()
 		return nil
 	})
 
+	err = fsoClient.WaitForOnline(ctx, cfg.OnlineTimeout, func(attempt int) {
+		events.publish(&pb.ServerEvent{
+			Type:    pb.ServerEvent_SettingUpServer,
+			Message: fmt.Sprintf("waiting for server API (attempt %v)", attempt),
+		})
+	})
 	if err != nil {
 		return
 	}
 
-	err = stream.Send(&pb.ServerEvent{Type: pb.ServerEvent_SettingUpServer, Message: imageName})
+	phase = pb.FailedPayload_ServerSetup
+	serverName := serverNamePrefix + in.Name
+	err = fsoClient.UpdateServer(ctx, fsoApi.ServerSettings{
+		Name:     serverName,
+		Password: password,
+		FrameCap: int(in.GetFrameCap()),
+	})
 	if err != nil {
 		return
 	}
 
-	fsoClient := fsoApi.NewClient(serverContainer.ApiPort)
+	events.publish(readyEvent(cfg, server, serverContainer, serverName))
+
+	// Kick of the management
+	go server.ManageServer(serverContainer, fsoClient, func(event servers.LifecycleEvent) {
+		events.publish(convertLifecycleEvent(event))
+	})
+
+	return nil
+}
+
+func validateStartRequest(in *pb.StartRequest) error {
+	if len(in.GetPassword()) > maxPasswordLength {
+		return fmt.Errorf("password may be at most %v characters long", maxPasswordLength)
+	}
+	for _, c := range in.GetPassword() {
+		if c <= ' ' || c > '~' {
+			return errors.New("password may only contain printable ASCII characters without spaces")
+		}
+	}
+
+	if in.GetFrameCap() > maxFrameCap {
+		return fmt.Errorf("frame cap may be at most %v", maxFrameCap)
+	}
+
+	return nil
+}
+
+func (s *workerServer) StartServer(in *pb.StartRequest, stream pb.CommNodeWorker_StartServerServer) error {
+	log.Printf("Starting server with name %v for %v", in.GetName(), in.GetOwner())
+
+	if err := validateStartRequest(in); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	password := in.GetPassword()
+	if in.GetPrivate() && password == "" {
+		var err error
+		password, err = generatePassword(generatedPasswordLength)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to generate password: %v", err)
+		}
+	}
 
-	err = fsoClient.WaitForOnline(stream.Context(), time.Second*5)
+	server, err := s.serverManager.CreateServer(in.GetName(), in.GetOwner())
 	if err != nil {
-		return
+		startErr := newStartError(pb.FailedPayload_Allocation, err)
+
+		// There is no server and so no event log but the stream of a failed start always ends with a Failed event
+		event := startErr.failedEvent()
+		event.Sequence = 1
+		event.Timestamp = timestamppb.Now()
+		if err := stream.Send(event); err != nil {
+			return streamError(err)
+		}
+
+		return startErr
+	}
+	events := newEventPublisher(server)
+
+	go func() {
+		// Errors are reported through the event log
+		_ = s.startServer(in, password, server, events)
+	}()
+
+	// Forward the startup events until the server is either ready or failed. Clients can use WatchServer to follow
+	// the server afterwards or if they lose their connection.
+	var startErr error
+	err = followEvents(stream.Context(), server.Events, func(event *pb.ServerEvent) (bool, error) {
+		if event.Type == pb.ServerEvent_ServerReady && password != "" {
+			// The log is shared with every watcher of the server so only the requester gets to see the password
+			event = proto.Clone(event).(*pb.ServerEvent)
+			event.GetReady().Password = password
+		}
+
+		if err := stream.Send(event); err != nil {
+			return true, err
+		}
+
+		switch event.Type {
+		case pb.ServerEvent_ServerReady:
+			return true, nil
+		case pb.ServerEvent_Failed:
+			startErr = startErrorFromPayload(event.GetFailure())
+			return true, nil
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return streamError(err)
 	}
 
-	serverName := "CommNode server " + in.Name
-	err = fsoClient.SetServerName(stream.Context(), serverName)
+	return startErr
+}
+
+func (s *workerServer) RotatePassword(ctx context.Context, in *pb.RotatePasswordRequest) (*pb.RotatePasswordResponse, error) {
+	log.Printf("Rotating password of server %v", in.GetServerId())
+
+	server := s.serverManager.FindServer(in.GetServerId())
+	if server == nil {
+		return nil, status.Errorf(codes.NotFound, "no server with id %q", in.GetServerId())
+	}
+
+	password, err := generatePassword(generatedPasswordLength)
 	if err != nil {
-		return
+		return nil, status.Errorf(codes.Internal, "failed to generate password: %v", err)
 	}
 
-	err = stream.Send(&pb.ServerEvent{Type: pb.ServerEvent_ServerReady, Message: serverName})
+	err = server.UpdateSettings(ctx, fsoApi.ServerSettings{Password: password})
+	if errors.Is(err, servers.ErrNotRunning) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
-		return
+		return nil, status.Errorf(codes.Unavailable, "failed to update password: %v", err)
 	}
 
-	// Kick of the management
-	go server.ManageServer(serverContainer, fsoClient)
+	return &pb.RotatePasswordResponse{Password: password}, nil
+}
+
+func (s *workerServer) WatchServer(in *pb.WatchRequest, stream pb.CommNodeWorker_WatchServerServer) error {
+	server := s.serverManager.FindServer(in.GetServerId())
+	if server == nil {
+		return status.Errorf(codes.NotFound, "no server with id %q", in.GetServerId())
+	}
+
+	err := followEvents(stream.Context(), server.Events, func(event *pb.ServerEvent) (bool, error) {
+		return false, stream.Send(event)
+	})
+	if err != nil {
+		return streamError(err)
+	}
 
 	return nil
 }
 
-func main() {
+func (s *workerServer) StopServer(ctx context.Context, in *pb.StopRequest) (*pb.StopResponse, error) {
+	log.Printf("Stopping server %v", in.GetServerId())
+
+	server := s.serverManager.FindServer(in.GetServerId())
+	if server == nil {
+		return nil, status.Errorf(codes.NotFound, "no server with id %q", in.GetServerId())
+	}
+
+	if err := server.Stop(ctx); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	return &pb.StopResponse{}, nil
+}
+
+func convertServerState(state servers.ServerState) pb.ServerStatus_State {
+	switch state {
+	case servers.StateRunning:
+		return pb.ServerStatus_Running
+	case servers.StateStopping:
+		return pb.ServerStatus_Stopping
+	case servers.StateStopped:
+		return pb.ServerStatus_Stopped
+	default:
+		return pb.ServerStatus_Starting
+	}
+}
+
+func (s *workerServer) getServerStatus(ctx context.Context, server *servers.Server) *pb.ServerStatus {
+	info := server.Info()
+	now := time.Now()
+
+	serverStatus := &pb.ServerStatus{
+		ServerId: info.Id,
+		Name:     info.Name,
+		Owner:    info.Owner,
+		State:    convertServerState(info.State),
+		UdpPort:  uint32(info.UdpPort),
+		Uptime:   durationpb.New(now.Sub(info.StartTime)),
+		IdleTime: durationpb.New(now.Sub(info.LastPlayerTime)),
+	}
+	if info.UdpPort == 0 && info.PortOffset >= 0 {
+		// The server is still starting but its port is already determined by the allocated offset
+		serverStatus.UdpPort = uint32(s.currentConfig().Containers.BaseUdpPort) + uint32(info.PortOffset)
+	}
+
+	playerCtx, cancel := context.WithTimeout(ctx, playerQueryTimeout)
+	defer cancel()
+
+	players, err := server.GetPlayers(playerCtx)
+	if err != nil {
+		// The status is still useful without the players so we do not fail here
+		log.Printf("Caught error while querying players of server %v: %v", info.Id, err)
+		return serverStatus
+	}
+
+	for _, player := range players {
+		serverStatus.Players = append(serverStatus.Players, convertPlayer(player))
+	}
+
+	return serverStatus
+}
+
+func (s *workerServer) ListServers(ctx context.Context, in *pb.ListServersRequest) (*pb.ListServersResponse, error) {
+	serverList := s.serverManager.Servers()
+	response := &pb.ListServersResponse{Servers: make([]*pb.ServerStatus, len(serverList))}
+
+	// The player queries are done in parallel so that a slow server does not delay the whole list
+	var wg sync.WaitGroup
+	for i, server := range serverList {
+		wg.Add(1)
+		go func(i int, server *servers.Server) {
+			defer wg.Done()
+			response.Servers[i] = s.getServerStatus(ctx, server)
+		}(i, server)
+	}
+	wg.Wait()
+
+	return response, nil
+}
+
+func (s *workerServer) GetServer(ctx context.Context, in *pb.GetServerRequest) (*pb.ServerStatus, error) {
+	server := s.serverManager.FindServer(in.GetServerId())
+	if server == nil {
+		return nil, status.Errorf(codes.NotFound, "no server with id %q", in.GetServerId())
+	}
+
+	return s.getServerStatus(ctx, server), nil
+}
+
+// newRuntime creates the runtime selected in the configuration. The returned function releases its resources.
+func newRuntime(cfg *config.Config) (containers.Runtime, func(), error) {
+	switch cfg.Runtime {
+	case config.RuntimeProcess:
+		return process.NewRuntime(cfg.Process), func() {}, nil
+	case config.RuntimeKubernetes:
+		clientset, err := kube.NewClientset(cfg.Kubernetes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return kube.NewRuntime(clientset, cfg.Kubernetes), func() {}, nil
+	}
+
 	dockerOpts, err := docker.GetDockerOptions()
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	dockerClient, err := client.NewClientWithOpts(dockerOpts...)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
-	defer func() {
+
+	return docker.NewRuntime(dockerClient), func() {
 		err := dockerClient.Close()
 		if err != nil {
 			panic(err)
 		}
-	}()
+	}, nil
+}
+
+func main() {
+	workerConfig, err := config.Load(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
 
-	err = docker.StopOldContainers(dockerClient)
+	runtime, closeRuntime, err := newRuntime(workerConfig)
 	if err != nil {
 		panic(err)
 	}
+	defer closeRuntime()
 
-	serverManager := servers.NewServerManager()
+	var dialApi fsoApi.DialFunc
+	if workerConfig.Runtime == config.RuntimeDocker {
+		// Servers on a remote Docker host only bind their API to the loopback interface of that host
+		dialApi, err = docker.GetApiDialer()
+		if err != nil {
+			panic(err)
+		}
+		if dialApi != nil && workerConfig.Containers.ProbePorts {
+			log.Printf("Not probing server ports since they are bound on the remote Docker host")
+		}
+	}
 
-	lis, err := net.Listen("tcp", grpcPort)
+	var worker *workerServer
+	serverManager := servers.NewServerManager(workerConfig.Servers, func(portOffset int32) bool {
+		cfg := worker.currentConfig()
+		if !cfg.Containers.ProbePorts || dialApi != nil {
+			// Probing only sees the ports of this machine which says nothing about a remote Docker host
+			return true
+		}
+
+		return containers.PortsAvailable(cfg.Containers, uint16(portOffset))
+	})
+
+	log.Printf("Game servers will be reachable at %v", workerConfig.PublicHost)
+
+	lis, err := net.Listen("tcp", workerConfig.ListenAddress)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
@@ -164,7 +588,27 @@ func main() {
 		s.GracefulStop()
 	})
 
-	pb.RegisterCommNodeWorkerServer(s, &workerServer{dockerClient: dockerClient, serverManager: serverManager})
+	worker = &workerServer{
+		runtime:       runtime,
+		dialApi:       dialApi,
+		serverManager: serverManager,
+		configName:    os.Args[0],
+		configArgs:    os.Args[1:],
+		config:        workerConfig,
+	}
+
+	// Servers which survived a restart of the worker need to be known before we accept new ones
+	err = worker.adoptServers(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	installReloadHandler(func() {
+		log.Printf("Caught hangup. Reloading configuration...")
+		worker.reloadConfig()
+	})
+
+	pb.RegisterCommNodeWorkerServer(s, worker)
 	reflection.Register(s)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/config"
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	"github.com/scp-fs2open/CommnodeWorker/containers/memory"
+	pb "github.com/scp-fs2open/CommnodeWorker/grpc"
+	"github.com/scp-fs2open/CommnodeWorker/servers"
+)
+
+// newTestWorker returns a worker with room for a single server which runs its servers in a memory runtime. The
+// server APIs are answered by the handler.
+func newTestWorker(t *testing.T, runtime *memory.Runtime, handler http.HandlerFunc) *workerServer {
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	cfg := config.Default()
+	cfg.OnlineTimeout = time.Millisecond * 300
+	cfg.Servers.MaxServers = 1
+	cfg.Servers.PortQuarantine = time.Second
+
+	return &workerServer{
+		runtime: runtime,
+		dialApi: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, api.Listener.Addr().String())
+		},
+		serverManager: servers.NewServerManager(cfg.Servers, func(portOffset int32) bool { return true }),
+		config:        &cfg,
+	}
+}
+
+func TestStartServerRollback(t *testing.T) {
+	injected := errors.New("injected failure")
+
+	tests := []struct {
+		name    string
+		prepare func(runtime *memory.Runtime)
+		handler http.HandlerFunc
+		phase   pb.FailedPayload_Phase
+	}{
+		{
+			name:    "pull",
+			prepare: func(runtime *memory.Runtime) { runtime.PullError = injected },
+			phase:   pb.FailedPayload_ImagePull,
+		},
+		{
+			name:    "create",
+			prepare: func(runtime *memory.Runtime) { runtime.CreateError = injected },
+			phase:   pb.FailedPayload_ContainerStart,
+		},
+		{
+			name:    "start",
+			prepare: func(runtime *memory.Runtime) { runtime.StartError = injected },
+			phase:   pb.FailedPayload_ContainerStart,
+		},
+		{
+			name: "pull while starting",
+			prepare: func(runtime *memory.Runtime) {
+				runtime.StartError = fmt.Errorf("%w: ImagePullBackOff", containers.ErrImagePull)
+			},
+			phase: pb.FailedPayload_ImagePull,
+		},
+		{
+			name: "online timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			phase: pb.FailedPayload_WaitForOnline,
+		},
+		{
+			name: "server setup",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/1/auth" {
+					http.Error(w, "broken", http.StatusInternalServerError)
+				}
+			},
+			phase: pb.FailedPayload_ServerSetup,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runtime := memory.NewRuntime()
+			if test.prepare != nil {
+				test.prepare(runtime)
+			}
+			worker := newTestWorker(t, runtime, test.handler)
+
+			server, err := worker.serverManager.CreateServer("Test", "Alpha 1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = worker.startServer(&pb.StartRequest{Name: "Test", Owner: "Alpha 1"}, "", server, newEventPublisher(server))
+
+			var startErr *startError
+			if !errors.As(err, &startErr) {
+				t.Fatalf("expected a start error, got %v", err)
+			}
+			if startErr.phase != test.phase {
+				t.Errorf("expected the start to fail in phase %v, got %v", test.phase, startErr.phase)
+			}
+
+			events, closed, err := server.Events.Read(context.Background(), 0)
+			if err != nil || !closed {
+				t.Fatalf("expected the event log to be closed, got %v", err)
+			}
+			if last := events[len(events)-1].(*pb.ServerEvent); last.Type != pb.ServerEvent_Failed || last.GetFailure().GetPhase() != test.phase {
+				t.Errorf("expected a failed event as the last event, got %v", last)
+			}
+
+			if ids := runtime.Containers(); len(ids) != 0 {
+				t.Errorf("expected no containers to be left, got %v", ids)
+			}
+			if worker.serverManager.FindServer(server.Id) != nil {
+				t.Error("expected the server to be removed")
+			}
+
+			// The port is freed in the background once the container is gone
+			deadline := time.Now().Add(time.Second * 5)
+			for {
+				next, err := worker.serverManager.CreateServer("Next", "Alpha 2")
+				if err == nil {
+					if next.PortOffset != 0 {
+						t.Errorf("expected the port offset to be reused, got %v", next.PortOffset)
+					}
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("port was not freed: %v", err)
+				}
+				time.Sleep(time.Millisecond * 10)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const (
+	// Leaves out characters which are easily confused with each other when typed off a screen
+	passwordAlphabet = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	generatedPasswordLength = 12
+
+	// Random API usernames keep others on the host from guessing them. Like the password nobody has to type them.
+	apiUsernameLength = 16
+
+	// Nobody has to type the server API password so it can be much longer
+	apiPasswordLength = 32
+)
+
+// generatePassword creates a random password of the specified length
+func generatePassword(length int) (string, error) {
+	alphabetSize := big.NewInt(int64(len(passwordAlphabet)))
+
+	password := make([]byte, length)
+	for i := range password {
+		index, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordAlphabet[index.Int64()]
+	}
+
+	return string(password), nil
+}
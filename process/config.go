@@ -0,0 +1,33 @@
+package process
+
+import (
+	"errors"
+)
+
+// Config contains the settings for running servers as child processes of the worker
+type Config struct {
+	// The fs2_open standalone server binary
+	BinaryPath string `yaml:"binary_path"`
+
+	// Every server gets its own working directory below this directory
+	WorkPath string `yaml:"work_path"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		BinaryPath: "/usr/local/bin/fs2_open",
+		WorkPath:   "/var/lib/commnode",
+	}
+}
+
+// Validate checks if the settings are usable
+func (c Config) Validate() error {
+	if c.BinaryPath == "" {
+		return errors.New("binary path must not be empty")
+	}
+	if c.WorkPath == "" {
+		return errors.New("work path must not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package process
+
+import "syscall"
+
+// serverProcAttr puts a server into its own process group so that signals meant for the worker, e.g. Ctrl+C in a
+// terminal, do not reach it directly. The kernel terminates the server if the worker dies without stopping it.
+func serverProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		Pdeathsig: syscall.SIGTERM,
+		Setpgid:   true,
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package process
+
+import "syscall"
+
+// serverProcAttr leaves the process attributes alone since the parent death signal is only available on Linux
+func serverProcAttr() *syscall.SysProcAttr {
+	return nil
+}
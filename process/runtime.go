@@ -0,0 +1,359 @@
+// Package process implements a container runtime which runs the standalone server binary directly as a child process
+// of the worker. It is meant for hosts which cannot run Docker.
+package process
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+)
+
+const (
+	// Receives stdout and stderr of the server inside its working directory
+	outputFileName = "output.log"
+
+	// The Docker image passes this in its entrypoint. The bare binary would start the game client otherwise.
+	standaloneFlag = "-standalone"
+)
+
+var errStatsUnsupported = errors.New("resource usage is not available for server processes")
+
+type serverProcess struct {
+	spec    containers.Spec
+	workDir string
+	cmd     *exec.Cmd
+	output  *os.File
+
+	exitCode   int64
+	exited     chan struct{}
+	removed    chan struct{}
+	finishOnce sync.Once
+}
+
+// Runtime runs every server as a child process. Processes are not adopted after a restart of the worker since they
+// are only known to the worker which started them.
+type Runtime struct {
+	config Config
+
+	mutex     sync.Mutex
+	processes map[string]*serverProcess
+}
+
+func NewRuntime(config Config) *Runtime {
+	return &Runtime{
+		config:    config,
+		processes: make(map[string]*serverProcess),
+	}
+}
+
+func newProcessId() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func (r *Runtime) started(p *serverProcess) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return p.cmd.Process != nil
+}
+
+func (r *Runtime) find(id string) (*serverProcess, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	p, ok := r.processes[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", containers.ErrNotFound, id)
+	}
+
+	return p, nil
+}
+
+// finish records the exit of a process and removes it together with its working directory. The process stays known
+// until its working directory is gone so that WaitForRemoval does not return early.
+func (r *Runtime) finish(id string, p *serverProcess, exitCode int64) {
+	p.finishOnce.Do(func() {
+		p.exitCode = exitCode
+		close(p.exited)
+
+		_ = p.output.Close()
+		if err := os.RemoveAll(p.workDir); err != nil {
+			log.Printf("Failed to remove working directory %v: %v", p.workDir, err)
+		}
+
+		r.mutex.Lock()
+		delete(r.processes, id)
+		r.mutex.Unlock()
+
+		close(p.removed)
+	})
+}
+
+// wait waits for the exit of a started process
+func (r *Runtime) wait(id string, p *serverProcess) {
+	err := p.cmd.Wait()
+
+	var exitCode int64
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// -1 if the process was killed by a signal
+		exitCode = int64(exitErr.ExitCode())
+	} else if err != nil {
+		log.Printf("Failed to wait for server process %v: %v", id, err)
+		exitCode = -1
+	}
+
+	r.finish(id, p, exitCode)
+}
+
+// Pull only checks that the server binary exists since there is no image to download
+func (r *Runtime) Pull(ctx context.Context, image string, progressCb containers.PullProgressCallback) error {
+	info, err := os.Stat(r.config.BinaryPath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		return fmt.Errorf("%v is not executable", r.config.BinaryPath)
+	}
+
+	return progressCb(containers.PullProgress{LayerId: filepath.Base(r.config.BinaryPath), Status: "Binary found"})
+}
+
+// linkGameData makes the game data available in the working directory. fs2_open looks for the game data in its
+// working directory so every entry of the data path is linked there.
+func linkGameData(workDir string, dataPath string) error {
+	entries, err := ioutil.ReadDir(dataPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.Symlink(filepath.Join(dataPath, entry.Name()), filepath.Join(workDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prepareWorkDir sets up the working directory of a server and opens the file which receives its output. The
+// working directory doubles as the preferences directory of the server so that it finds its configuration there.
+func prepareWorkDir(workDir string, spec containers.Spec) (*os.File, error) {
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err := linkGameData(workDir, spec.DataPath); err != nil {
+		return nil, fmt.Errorf("failed to link game data: %w", err)
+	}
+
+	configPath := filepath.Join(workDir, filepath.FromSlash(containers.ServerConfigPath))
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(configPath, containers.ServerConfig(spec), 0600); err != nil {
+		return nil, err
+	}
+
+	return os.Create(filepath.Join(workDir, outputFileName))
+}
+
+func (r *Runtime) Create(ctx context.Context, spec containers.Spec) (string, error) {
+	id, err := newProcessId()
+	if err != nil {
+		return "", err
+	}
+
+	workDir := filepath.Join(r.config.WorkPath, spec.Name)
+	output, err := prepareWorkDir(workDir, spec)
+	if err != nil {
+		_ = os.RemoveAll(workDir)
+		return "", err
+	}
+
+	// The process has to outlive the request which started it so it is not bound to the context
+	cmd := exec.Command(r.config.BinaryPath, append([]string{standaloneFlag}, spec.Args...)...)
+	cmd.Dir = workDir
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.Env = append(os.Environ(), containers.PrefPathEnv+"="+workDir)
+	cmd.SysProcAttr = serverProcAttr()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.processes[id] = &serverProcess{
+		spec:    spec,
+		workDir: workDir,
+		cmd:     cmd,
+		output:  output,
+		exited:  make(chan struct{}),
+		removed: make(chan struct{}),
+	}
+
+	return id, nil
+}
+
+func (r *Runtime) Start(ctx context.Context, id string, progressCb containers.PullProgressCallback) error {
+	p, err := r.find(id)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	err = p.cmd.Start()
+	r.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Started server process %v (pid %v) in %v", id, p.cmd.Process.Pid, p.workDir)
+	go r.wait(id, p)
+
+	return nil
+}
+
+func (r *Runtime) Wait(ctx context.Context, id string) (int64, error) {
+	p, err := r.find(id)
+	if err != nil {
+		return -1, err
+	}
+
+	select {
+	case <-p.exited:
+		return p.exitCode, nil
+	case <-ctx.Done():
+		return -1, ctx.Err()
+	}
+}
+
+func (r *Runtime) Stop(ctx context.Context, id string) error {
+	p, err := r.find(id)
+	if err != nil {
+		return err
+	}
+
+	if !r.started(p) {
+		r.finish(id, p, -1)
+		return nil
+	}
+
+	if err := p.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		// Signals other than kill are not supported everywhere
+		return p.cmd.Process.Kill()
+	}
+
+	select {
+	case <-p.exited:
+		return nil
+	case <-time.After(p.spec.StopTimeout):
+		log.Printf("Server process %v did not exit in time. Killing it...", id)
+		return p.cmd.Process.Kill()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Runtime) Remove(ctx context.Context, id string) error {
+	p, err := r.find(id)
+	if err != nil {
+		return err
+	}
+
+	if !r.started(p) {
+		r.finish(id, p, -1)
+		return nil
+	}
+
+	if err := p.cmd.Process.Kill(); err != nil {
+		select {
+		case <-p.exited:
+			// Exited on its own in the meantime
+		default:
+			return err
+		}
+	}
+
+	return r.WaitForRemoval(ctx, id)
+}
+
+func (r *Runtime) WaitForRemoval(ctx context.Context, id string) error {
+	p, err := r.find(id)
+	if err != nil {
+		// Already gone
+		return nil
+	}
+
+	select {
+	case <-p.removed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Runtime) Logs(ctx context.Context, id string) (io.ReadCloser, error) {
+	p, err := r.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(p.output.Name())
+}
+
+func (r *Runtime) Stats(ctx context.Context, id string) (containers.Stats, error) {
+	if _, err := r.find(id); err != nil {
+		return containers.Stats{}, err
+	}
+
+	return containers.Stats{}, errStatsUnsupported
+}
+
+func (r *Runtime) Inspect(ctx context.Context, id string) (containers.Info, error) {
+	p, err := r.find(id)
+	if err != nil {
+		return containers.Info{}, err
+	}
+
+	return containers.Info{
+		Id:      id,
+		Image:   p.spec.Image,
+		Labels:  p.spec.Labels,
+		ApiPort: p.spec.ApiPort,
+		UdpPort: p.spec.UdpPort,
+
+		ApiUsername: p.spec.ApiUsername,
+		ApiPassword: p.spec.ApiPassword,
+	}, nil
+}
+
+func (r *Runtime) List(ctx context.Context, label string) ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var ids []string
+	for id, p := range r.processes {
+		if _, ok := p.spec.Labels[label]; ok && p.cmd.Process != nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
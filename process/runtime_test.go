@@ -0,0 +1,268 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+)
+
+// stubServer is a shell script standing in for the server binary. It prints what it was started with, waits until
+// it is released and then exits with the configured code.
+type stubServer struct {
+	path        string
+	releasePath string
+}
+
+func newStubServer(t *testing.T, exitCode int, ignoreTerm bool) *stubServer {
+	dir := t.TempDir()
+	stub := &stubServer{
+		path:        filepath.Join(dir, "fs2_open"),
+		releasePath: filepath.Join(dir, "release"),
+	}
+
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\n")
+	if ignoreTerm {
+		script.WriteString("trap '' TERM\n")
+	}
+	script.WriteString(`echo "args: $*"
+echo "cwd: $(pwd)"
+echo "prefs: $XDG_DATA_HOME"
+cat "$XDG_DATA_HOME/HardLightProductions/FreeSpaceOpen/data/multi.cfg"
+ls
+echo "ready"
+`)
+	fmt.Fprintf(&script, "while [ ! -e %q ]; do sleep 0.05; done\n", stub.releasePath)
+	fmt.Fprintf(&script, "exit %v\n", exitCode)
+
+	if err := ioutil.WriteFile(stub.path, []byte(script.String()), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return stub
+}
+
+// release lets the stub exit
+func (s *stubServer) release(t *testing.T) {
+	if err := ioutil.WriteFile(s.releasePath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestRuntime creates a runtime for the stub and a game data directory
+func newTestRuntime(t *testing.T, stub *stubServer) (*Runtime, containers.Spec) {
+	dir := t.TempDir()
+
+	dataPath := filepath.Join(dir, "data")
+	if err := os.MkdirAll(filepath.Join(dataPath, "data", "missions"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dataPath, "root_fs2.vp"), []byte("VPVP"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	runtime := NewRuntime(Config{BinaryPath: stub.path, WorkPath: filepath.Join(dir, "work")})
+	spec := containers.Spec{
+		Name:        "fso-0123456789abcdef",
+		Image:       "scpfs2open/fso-standalone:release",
+		Labels:      map[string]string{"fso_server": ""},
+		Args:        []string{"-port", "7810"},
+		ApiPort:     8082,
+		UdpPort:     7810,
+		ApiUsername: "user",
+		ApiPassword: "secret",
+		DataPath:    dataPath,
+		StopTimeout: time.Millisecond * 300,
+	}
+
+	return runtime, spec
+}
+
+// waitForOutput polls the output of the server until it contains the text
+func waitForOutput(t *testing.T, runtime *Runtime, id string, text string) string {
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		logs, err := runtime.Logs(context.Background(), id)
+		if err != nil {
+			t.Fatalf("failed to read the output: %v", err)
+		}
+		output, _ := ioutil.ReadAll(logs)
+		_ = logs.Close()
+
+		if strings.Contains(string(output), text) {
+			return string(output)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %q in the output:\n%s", text, output)
+		}
+		time.Sleep(time.Millisecond * 20)
+	}
+}
+
+func startStub(t *testing.T, runtime *Runtime, spec containers.Spec) string {
+	ctx := context.Background()
+
+	if err := runtime.Pull(ctx, spec.Image, func(progress containers.PullProgress) error { return nil }); err != nil {
+		t.Fatalf("the stub binary was not found: %v", err)
+	}
+
+	id, err := runtime.Create(ctx, spec)
+	if err != nil {
+		t.Fatalf("failed to create the process: %v", err)
+	}
+	if err := runtime.Start(ctx, id, nil); err != nil {
+		t.Fatalf("failed to start the process: %v", err)
+	}
+
+	return id
+}
+
+func assertRemoved(t *testing.T, runtime *Runtime, id string, workDir string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	if err := runtime.WaitForRemoval(ctx, id); err != nil {
+		t.Fatalf("the process was not removed: %v", err)
+	}
+	if _, err := os.Stat(workDir); !os.IsNotExist(err) {
+		t.Errorf("expected the working directory to be removed: %v", err)
+	}
+	if _, err := runtime.Inspect(ctx, id); !errors.Is(err, containers.ErrNotFound) {
+		t.Errorf("expected the process to be gone, got %v", err)
+	}
+}
+
+func TestProcessExit(t *testing.T) {
+	stub := newStubServer(t, 3, false)
+	runtime, spec := newTestRuntime(t, stub)
+	workDir := filepath.Join(runtime.config.WorkPath, spec.Name)
+
+	id := startStub(t, runtime, spec)
+	output := waitForOutput(t, runtime, id, "ready")
+
+	for _, expected := range []string{
+		"args: -standalone -port 7810\n",
+		"cwd: " + workDir + "\n",
+		"prefs: " + workDir + "\n",
+		"+webapiport 8082\n",
+		"+webapiusername user\n",
+		"+webapipassword secret\n",
+		"root_fs2.vp\n",
+		"output.log\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in the output:\n%v", expected, output)
+		}
+	}
+
+	if target, err := os.Readlink(filepath.Join(workDir, "root_fs2.vp")); err != nil || target != filepath.Join(spec.DataPath, "root_fs2.vp") {
+		t.Errorf("expected the game data to be linked into the working directory: %v %v", target, err)
+	}
+
+	ids, err := runtime.List(context.Background(), "fso_server")
+	if err != nil || len(ids) != 1 || ids[0] != id {
+		t.Errorf("expected the running process to be listed, got %v %v", ids, err)
+	}
+
+	stub.release(t)
+
+	exitCode, err := runtime.Wait(context.Background(), id)
+	if err != nil {
+		t.Fatalf("failed to wait for the process: %v", err)
+	}
+	if exitCode != 3 {
+		t.Errorf("expected exit code 3, got %v", exitCode)
+	}
+
+	assertRemoved(t, runtime, id, workDir)
+}
+
+func TestProcessStopKillsAfterTimeout(t *testing.T) {
+	stub := newStubServer(t, 0, true)
+	runtime, spec := newTestRuntime(t, stub)
+	workDir := filepath.Join(runtime.config.WorkPath, spec.Name)
+
+	id := startStub(t, runtime, spec)
+	waitForOutput(t, runtime, id, "ready")
+
+	exitCodes := make(chan int64, 1)
+	go func() {
+		exitCode, _ := runtime.Wait(context.Background(), id)
+		exitCodes <- exitCode
+	}()
+
+	start := time.Now()
+	if err := runtime.Stop(context.Background(), id); err != nil {
+		t.Fatalf("failed to stop the process: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < spec.StopTimeout {
+		t.Errorf("the process ignores SIGTERM but was gone after %v", elapsed)
+	}
+
+	select {
+	case exitCode := <-exitCodes:
+		if exitCode != -1 {
+			t.Errorf("expected exit code -1 for a killed process, got %v", exitCode)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("the process was not killed")
+	}
+
+	assertRemoved(t, runtime, id, workDir)
+}
+
+func TestProcessStopGraceful(t *testing.T) {
+	stub := newStubServer(t, 0, false)
+	runtime, spec := newTestRuntime(t, stub)
+	spec.StopTimeout = time.Second * 10
+
+	id := startStub(t, runtime, spec)
+	waitForOutput(t, runtime, id, "ready")
+
+	start := time.Now()
+	if err := runtime.Stop(context.Background(), id); err != nil {
+		t.Fatalf("failed to stop the process: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= spec.StopTimeout {
+		t.Errorf("expected SIGTERM to end the process, took %v", elapsed)
+	}
+
+	assertRemoved(t, runtime, id, filepath.Join(runtime.config.WorkPath, spec.Name))
+}
+
+func TestProcessRemoveBeforeStart(t *testing.T) {
+	stub := newStubServer(t, 0, false)
+	runtime, spec := newTestRuntime(t, stub)
+	workDir := filepath.Join(runtime.config.WorkPath, spec.Name)
+
+	id, err := runtime.Create(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("failed to create the process: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, filepath.FromSlash(containers.ServerConfigPath))); err != nil {
+		t.Errorf("expected the configuration to be written on create: %v", err)
+	}
+
+	if err := runtime.Remove(context.Background(), id); err != nil {
+		t.Fatalf("failed to remove the process: %v", err)
+	}
+
+	assertRemoved(t, runtime, id, workDir)
+}
+
+func TestProcessPullMissingBinary(t *testing.T) {
+	runtime := NewRuntime(Config{BinaryPath: filepath.Join(t.TempDir(), "missing"), WorkPath: t.TempDir()})
+
+	err := runtime.Pull(context.Background(), "", func(progress containers.PullProgress) error { return nil })
+	if err == nil {
+		t.Error("expected an error for a missing binary")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// How much time all undo actions of a rollback get in total
+	rollbackTimeout = time.Second * 30
+)
+
+type undoAction struct {
+	name string
+	undo func(ctx context.Context) error
+}
+
+// rollback collects undo actions for the steps of an operation so that they can be reverted in reverse order if a
+// later step fails
+type rollback struct {
+	actions []undoAction
+}
+
+func (r *rollback) add(name string, undo func(ctx context.Context) error) {
+	r.actions = append(r.actions, undoAction{name: name, undo: undo})
+}
+
+// run executes all undo actions in reverse order. This does not use the context of the operation since that might be
+// the reason why we are rolling back in the first place.
+func (r *rollback) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+	defer cancel()
+
+	for i := len(r.actions) - 1; i >= 0; i-- {
+		action := r.actions[i]
+
+		log.Printf("Rolling back %v", action.name)
+		if err := action.undo(ctx); err != nil {
+			log.Printf("Caught error while rolling back %v: %v", action.name, err)
+		}
+	}
+
+	r.actions = nil
+}
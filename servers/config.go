@@ -0,0 +1,66 @@
+package servers
+
+import (
+	"errors"
+	"time"
+)
+
+// Config contains the settings for managing running servers
+type Config struct {
+	// How many servers may run at the same time. This also limits the port range used by the servers.
+	MaxServers int `yaml:"max_servers"`
+
+	// How often the players of a server are checked
+	CheckInterval time.Duration `yaml:"check_interval"`
+
+	// How long a server may be without players before it is shut down
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+
+	// How long before the idle shutdown a warning is sent. Must be at least the check interval so that a check falls
+	// into the warning window.
+	IdleWarning time.Duration `yaml:"idle_warning"`
+
+	// How long the port of a stopped server is kept unused if the removal of its container cannot be confirmed
+	PortQuarantine time.Duration `yaml:"port_quarantine"`
+
+	// Whether servers are stopped when the worker shuts down. If they keep running, the next start of the worker
+	// adopts them. Unlike the other settings this also applies to servers which were created before a reload.
+	StopOnShutdown bool `yaml:"stop_on_shutdown"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxServers:    16,
+		CheckInterval: time.Second * 30,
+		// 5 Minutes should be enough for the requester to join a game
+		IdleTimeout:    time.Minute * 5,
+		IdleWarning:    time.Minute,
+		PortQuarantine: time.Second * 30,
+	}
+}
+
+// Validate checks if the settings are usable
+func (c Config) Validate() error {
+	if c.MaxServers < 1 {
+		return errors.New("at least one server must be allowed")
+	}
+	if c.CheckInterval < time.Second {
+		return errors.New("check interval must be at least one second")
+	}
+	if c.IdleTimeout < c.CheckInterval {
+		return errors.New("idle timeout must not be shorter than the check interval")
+	}
+	if c.IdleWarning >= c.IdleTimeout {
+		return errors.New("idle warning must be shorter than the idle timeout")
+	}
+	if c.IdleWarning < c.CheckInterval {
+		// Otherwise the warning window may fall between two player checks
+		return errors.New("idle warning must not be shorter than the check interval")
+	}
+
+	if c.PortQuarantine < 0 {
+		return errors.New("port quarantine must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package servers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigValidateIdleWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		idleWarning time.Duration
+		valid       bool
+	}{
+		{"default", time.Minute, true},
+		{"same as check interval", time.Second * 30, true},
+		{"shorter than check interval", time.Second * 10, false},
+		{"disabled", 0, false},
+		{"same as idle timeout", time.Minute * 5, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.IdleWarning = test.idleWarning
+
+			err := config.Validate()
+			if test.valid && err != nil {
+				t.Errorf("expected the config to be valid: %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected the config to be rejected")
+			}
+		})
+	}
+}
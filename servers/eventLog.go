@@ -0,0 +1,80 @@
+package servers
+
+import (
+	"context"
+	"sync"
+)
+
+// EventLog records the events of a server so that any number of watchers can replay them and then follow new ones
+type EventLog struct {
+	mutex  sync.Mutex
+	events []interface{}
+	closed bool
+
+	// Closed and replaced whenever the log changes
+	changed chan struct{}
+}
+
+func NewEventLog() *EventLog {
+	return &EventLog{
+		events:  make([]interface{}, 0),
+		changed: make(chan struct{}),
+	}
+}
+
+func (l *EventLog) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// Publish appends an event to the log. Events published after the log was closed are dropped.
+func (l *EventLog) Publish(event interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	l.events = append(l.events, event)
+	l.notify()
+}
+
+// Close marks the log as complete. Readers will receive all remaining events and are then told that no more will
+// follow.
+func (l *EventLog) Close() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	l.closed = true
+	l.notify()
+}
+
+// Read returns all events starting at the specified index. If there are none yet, this blocks until new events
+// arrive, the log is closed or the context is done. closed is true if no events will follow the returned ones.
+func (l *EventLog) Read(ctx context.Context, from int) (events []interface{}, closed bool, err error) {
+	for {
+		l.mutex.Lock()
+		if from < len(l.events) || l.closed {
+			if from < len(l.events) {
+				events = make([]interface{}, len(l.events)-from)
+				copy(events, l.events[from:])
+			}
+			closed = l.closed
+			l.mutex.Unlock()
+			return events, closed, nil
+		}
+		changed := l.changed
+		l.mutex.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+}
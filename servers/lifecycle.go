@@ -0,0 +1,45 @@
+package servers
+
+import (
+	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
+	"time"
+)
+
+// LifecycleEventType identifies what happened to a running server
+type LifecycleEventType int32
+
+const (
+	LifecyclePlayerJoined LifecycleEventType = iota
+	LifecyclePlayerLeft
+	LifecycleIdleWarning
+	LifecycleStopped
+)
+
+// StopReason describes why a server stopped
+type StopReason int32
+
+const (
+	StopReasonIdle StopReason = iota
+	StopReasonRequested
+	StopReasonShutdown
+	StopReasonExited
+)
+
+// LifecycleEvent is reported by ManageServer whenever something noteworthy happens to a running server
+type LifecycleEvent struct {
+	Type LifecycleEventType
+
+	// The player which joined or left
+	Player fsoApi.PlayerData
+
+	// How long the server has been idle and how much time is left until it is shut down for an IdleWarning
+	IdleTime     time.Duration
+	IdleTimeLeft time.Duration
+
+	// Why the server stopped and the exit code of its container for a Stopped event. The exit code is -1 if it is
+	// unknown.
+	StopReason StopReason
+	ExitCode   int64
+}
+
+type LifecycleListener = func(event LifecycleEvent)
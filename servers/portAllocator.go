@@ -0,0 +1,66 @@
+package servers
+
+import (
+	"errors"
+	"log"
+	"sync"
+)
+
+// ErrNoFreePorts is returned when the maximum number of servers is running or all remaining ports are in use
+var ErrNoFreePorts = errors.New("no free ports available")
+
+// PortProbe checks if the host ports of a port offset can currently be used
+type PortProbe = func(portOffset int32) bool
+
+// portAllocator hands out port offsets from a bounded range
+type portAllocator struct {
+	mutex sync.Mutex
+	used  map[int32]bool
+
+	probe PortProbe
+}
+
+func newPortAllocator(probe PortProbe) *portAllocator {
+	return &portAllocator{
+		used:  make(map[int32]bool),
+		probe: probe,
+	}
+}
+
+// allocate returns the lowest unused port offset below maxServers whose host ports are available
+func (a *portAllocator) allocate(maxServers int) (int32, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for offset := int32(0); offset < int32(maxServers); offset++ {
+		if a.used[offset] {
+			continue
+		}
+
+		if a.probe != nil && !a.probe(offset) {
+			// Something else on the host uses this port so try the next one
+			log.Printf("Ports of offset %v are in use by another process. Skipping them...", offset)
+			continue
+		}
+
+		a.used[offset] = true
+		return offset, nil
+	}
+
+	return -1, ErrNoFreePorts
+}
+
+// reserve marks a specific port offset as used. This may be outside of the allocation range.
+func (a *portAllocator) reserve(offset int32) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.used[offset] = true
+}
+
+func (a *portAllocator) free(offset int32) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	delete(a.used, offset)
+}
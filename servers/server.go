@@ -2,64 +2,249 @@ package servers
 
 import (
 	"context"
-	"github.com/scp-fs2open/CommnodeWorker/docker"
+	"errors"
+	"github.com/scp-fs2open/CommnodeWorker/containers"
 	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
 	"log"
+	"sync"
 	"time"
 )
 
 const (
-	// 5 Minutes should be enough for the requester to join a game
-	noPlayerTimeout = time.Minute * 5
+	// How long we wait for the container to report its exit code after it was stopped
+	exitCodeTimeout = time.Second * 10
 )
 
+// ErrNotRunning is returned for operations which require a running server
+var ErrNotRunning = errors.New("server is not running")
+
+// ServerState describes in which phase of its lifetime a server currently is
+type ServerState int32
+
+const (
+	StateStarting ServerState = iota
+	StateRunning
+	StateStopping
+	StateStopped
+)
+
+func (s ServerState) String() string {
+	switch s {
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateStopping:
+		return "stopping"
+	case StateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// ServerInfo is a snapshot of the metadata of a managed server
+type ServerInfo struct {
+	Id          string
+	Name        string
+	Owner       string
+	PortOffset  int32
+	UdpPort     uint16
+	ContainerId string
+	StartTime   time.Time
+	State       ServerState
+
+	// The last time at which players were seen on the server
+	LastPlayerTime time.Time
+}
+
 type freePortCallback = func(port int32)
 
+type removeCallback = func(id string)
+
+type stopOnShutdownCallback = func() bool
+
 type Server struct {
-	PortOffset int32
+	Id    string
+	Name  string
+	Owner string
+
+	StartTime time.Time
+
+	// Records everything that happened to this server
+	Events *EventLog
+
+	// The settings at the time the server was created
+	config Config
 
 	serverContext context.Context
 
-	container *docker.ServerContainer
+	// Cancelled as soon as the server should stop. Used for aborting the startup of the server.
+	startContext context.Context
+
+	// Protects the mutable server state below
+	mutex sync.Mutex
+
+	PortOffset int32
+
+	state ServerState
+
+	container *containers.ServerContainer
 
 	serverApi *fsoApi.Client
 
 	lastPlayerTime time.Time
 
+	// The players seen during the last player check, keyed by their ID
+	players map[int32]fsoApi.PlayerData
+
+	idleWarningSent bool
+
+	listener LifecycleListener
+
 	freePortCb freePortCallback
 
+	removeCb removeCallback
+
+	stopOnShutdownCb stopOnShutdownCallback
+
 	shutdown <-chan struct{}
+
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	done        chan struct{}
+	releaseOnce sync.Once
+}
+
+func (s *Server) setState(state ServerState) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.state = state
+}
+
+// Info returns a snapshot of the current server metadata
+func (s *Server) Info() ServerInfo {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	info := ServerInfo{
+		Id:         s.Id,
+		Name:       s.Name,
+		Owner:      s.Owner,
+		PortOffset: s.PortOffset,
+		StartTime:  s.StartTime,
+		State:      s.state,
+
+		LastPlayerTime: s.lastPlayerTime,
+	}
+
+	if s.container != nil {
+		info.UdpPort = s.container.UdpPort
+		info.ContainerId = s.container.ContainerId()
+	}
+
+	return info
+}
+
+// GetPlayers queries the players currently connected to the server. Servers which are still starting have no players.
+func (s *Server) GetPlayers(ctx context.Context) ([]fsoApi.PlayerData, error) {
+	s.mutex.Lock()
+	serverApi := s.serverApi
+	s.mutex.Unlock()
+
+	if serverApi == nil {
+		return nil, nil
+	}
+
+	return serverApi.GetPlayers(ctx)
+}
+
+// UpdateSettings changes the settings of the running server
+func (s *Server) UpdateSettings(ctx context.Context, settings fsoApi.ServerSettings) error {
+	s.mutex.Lock()
+	serverApi := s.serverApi
+	state := s.state
+	s.mutex.Unlock()
+
+	if serverApi == nil || state != StateRunning {
+		return ErrNotRunning
+	}
+
+	return serverApi.UpdateServer(ctx, settings)
 }
 
 func (s *Server) stopServer() {
-	log.Printf("Shutting down server")
+	log.Printf("Shutting down server %v", s.Id)
+	s.setState(StateStopping)
+
 	err := s.container.StopContainer(s.serverContext)
 	if err != nil {
 		log.Printf("Caught error while stopping container: %v", err)
 	}
 }
 
+func (s *Server) updatePlayers(players []fsoApi.PlayerData) {
+	current := make(map[int32]fsoApi.PlayerData, len(players))
+	for _, player := range players {
+		current[player.Id] = player
+
+		if _, ok := s.players[player.Id]; !ok {
+			s.listener(LifecycleEvent{Type: LifecyclePlayerJoined, Player: player})
+		}
+	}
+
+	for id, player := range s.players {
+		if _, ok := current[id]; !ok {
+			s.listener(LifecycleEvent{Type: LifecyclePlayerLeft, Player: player})
+		}
+	}
+
+	s.players = current
+}
+
 func (s *Server) checkPlayerCount() bool {
-	log.Printf("Checking player status of server")
+	log.Printf("Checking player status of server %v", s.Id)
 	players, err := s.serverApi.GetPlayers(s.serverContext)
 
-	if err != nil {
+	if errors.Is(err, fsoApi.ErrServerUnreachable) {
+		// Usually the container is gone which the management loop notices on its own. Otherwise the server does not
+		// count as active anymore so that the idle timeout eventually removes it.
+		log.Printf("Server %v is unreachable: %v", s.Id, err)
+	} else if err != nil {
+		// A slow or failing response does not mean that nobody is playing
 		log.Printf("Caught error while checking player count: %v", err)
 		return true
+	} else {
+		s.updatePlayers(players)
 	}
 
 	now := time.Now()
 
+	s.mutex.Lock()
 	// Check if we currently have some players
 	if len(players) > 0 {
 		// We are active!
 		s.lastPlayerTime = now
-		return true
+	}
+	idleTime := now.Sub(s.lastPlayerTime)
+	s.mutex.Unlock()
+
+	if len(players) > 0 {
+		s.idleWarningSent = false
+	} else if idleTime > s.config.IdleTimeout-s.config.IdleWarning && idleTime <= s.config.IdleTimeout && !s.idleWarningSent {
+		s.idleWarningSent = true
+		s.listener(LifecycleEvent{
+			Type:         LifecycleIdleWarning,
+			IdleTime:     idleTime,
+			IdleTimeLeft: s.config.IdleTimeout - idleTime,
+		})
 	}
 
 	// Check if we ran into our timeout
-	if now.Sub(s.lastPlayerTime) <= noPlayerTimeout {
-		// No players are active but we still have time left
+	if idleTime <= s.config.IdleTimeout {
+		// Either players are active or we still have time left
 		return true
 	}
 
@@ -70,27 +255,73 @@ func (s *Server) checkPlayerCount() bool {
 	return false
 }
 
-func (s *Server) ManageServer(container *docker.ServerContainer, serverApi *fsoApi.Client) {
+// waitForExitCode waits a bit for the exit code of a container we stopped ourselves
+func waitForExitCode(containerExit <-chan int64) int64 {
+	select {
+	case exitCode := <-containerExit:
+		return exitCode
+	case <-time.After(exitCodeTimeout):
+		return -1
+	}
+}
+
+// AttachContainer assigns the container of a server which is still starting. If the start fails, releasing the
+// server waits for the removal of the container before its port is handed out again.
+func (s *Server) AttachContainer(container *containers.ServerContainer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.container = container
+}
+
+// ManageServer watches the running server until it stops. Noteworthy changes are reported to the listener.
+func (s *Server) ManageServer(container *containers.ServerContainer, serverApi *fsoApi.Client, listener LifecycleListener) {
+	s.mutex.Lock()
 	s.container = container
 	s.serverApi = serverApi
+	s.state = StateRunning
+	s.mutex.Unlock()
+
+	s.listener = listener
 
 	containerExit := s.container.WaitForNotRunning(s.serverContext)
 
+	var stopReason StopReason
+	var exitCode int64
+
 	alive := true
 	for alive {
 		alive = false
 
 		select {
 		case <-s.shutdown:
+			if !s.stopOnShutdownCb() {
+				// The server keeps running and will be adopted by the next worker
+				log.Printf("Leaving server %v running", s.Id)
+				s.Release()
+				return
+			}
+
 			// We were stopped forcefully so shut down the server
 			s.stopServer()
+			stopReason = StopReasonShutdown
+			exitCode = waitForExitCode(containerExit)
+			break
+		case <-s.stop:
+			// Someone requested that this server should be stopped
+			s.stopServer()
+			stopReason = StopReasonRequested
+			exitCode = waitForExitCode(containerExit)
 			break
-		case exitCode := <-containerExit:
+		case exitCode = <-containerExit:
 			// Stop the management coroutine
 			log.Printf("Container exited with code %v", exitCode)
+			stopReason = StopReasonExited
 			break
-		case <-time.After(30 * time.Second):
+		case <-time.After(s.config.CheckInterval):
 			if !s.checkPlayerCount() {
+				stopReason = StopReasonIdle
+				exitCode = waitForExitCode(containerExit)
 				break
 			}
 			// This is the only case where we stay in the loop
@@ -98,10 +329,62 @@ func (s *Server) ManageServer(container *docker.ServerContainer, serverApi *fsoA
 		}
 	}
 
-	s.FreePort()
+	s.listener(LifecycleEvent{Type: LifecycleStopped, StopReason: stopReason, ExitCode: exitCode})
+
+	s.Release()
+}
+
+// StartContext returns a context which is cancelled once the server is requested to stop or the manager shuts
+// down. Starting the server should use this so that a stop request aborts the startup.
+func (s *Server) StartContext() context.Context {
+	return s.startContext
+}
+
+// Stop requests that the server should be shut down and waits until its management loop has finished
+func (s *Server) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the port of the server and removes it from its manager
+func (s *Server) Release() {
+	s.releaseOnce.Do(func() {
+		s.FreePort()
+		s.setState(StateStopped)
+		s.removeCb(s.Id)
+		s.Events.Close()
+		close(s.done)
+	})
+}
+
+// releasePort returns the port offset to the manager once the container of the server is gone. If that cannot be
+// confirmed, the port is kept in quarantine for a while instead.
+func (s *Server) releasePort(port int32, container *containers.ServerContainer) {
+	if container != nil {
+		ctx, cancel := context.WithTimeout(s.serverContext, s.config.PortQuarantine)
+		defer cancel()
+
+		if err := container.WaitForRemoval(ctx); err != nil {
+			log.Printf("Could not confirm removal of container for server %v (%v). Keeping port in quarantine...", s.Id, err)
+			<-ctx.Done()
+		}
+	}
+
+	s.freePortCb(port)
 }
 
 func (s *Server) FreePort() {
-	go s.freePortCb(s.PortOffset)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	go s.releasePort(s.PortOffset, s.container)
 	s.PortOffset = -1
 }
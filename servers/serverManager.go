@@ -2,63 +2,157 @@ package servers
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
 	"sync"
 	"time"
 )
 
 type ServerManager struct {
-	portMutex sync.Mutex
-	freePorts []int32
-	nextPort  int32
+	ports *portAllocator
+
+	serversMutex sync.Mutex
+	servers      map[string]*Server
+
+	configMutex sync.Mutex
+	config      Config
 
 	managerContext context.Context
 
 	shutdownServers chan struct{}
 }
 
-func NewServerManager() *ServerManager {
+// NewServerManager creates a new manager. The probe is used for checking that the ports of a server are not in use
+// by something else before they are handed out.
+func NewServerManager(config Config, probe PortProbe) *ServerManager {
 	return &ServerManager{
-		freePorts:      make([]int32, 0),
-		nextPort:       0,
-		managerContext: context.Background(),
+		config:          config,
+		ports:           newPortAllocator(probe),
+		servers:         make(map[string]*Server),
+		managerContext:  context.Background(),
 		shutdownServers: make(chan struct{}),
 	}
 }
 
-func (s *ServerManager) allocatePort() int32 {
-	s.portMutex.Lock()
-	defer s.portMutex.Unlock()
-
-	if len(s.freePorts) > 0 {
-		// Take a port from the free list
-		port := s.freePorts[len(s.freePorts)-1]
-		s.freePorts = s.freePorts[:len(s.freePorts)-1]
-		return port
+func newServerId() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
 	}
+	return hex.EncodeToString(id)
+}
+
+func (s *ServerManager) removeServer(id string) {
+	s.serversMutex.Lock()
+	defer s.serversMutex.Unlock()
+
+	delete(s.servers, id)
+}
+
+// SetConfig replaces the settings used for new servers. Running servers keep the settings they were created with
+// except for StopOnShutdown which is looked up when the worker shuts down.
+func (s *ServerManager) SetConfig(config Config) {
+	s.configMutex.Lock()
+	defer s.configMutex.Unlock()
 
-	// Use a new port
-	port := s.nextPort
-	s.nextPort += 1
-	return port
+	s.config = config
 }
 
-func (s *ServerManager) freePort(port int32) {
-	s.portMutex.Lock()
-	defer s.portMutex.Unlock()
+func (s *ServerManager) currentConfig() Config {
+	s.configMutex.Lock()
+	defer s.configMutex.Unlock()
 
-	s.freePorts = append(s.freePorts, port)
+	return s.config
 }
 
-func (s *ServerManager) CreateServer() *Server {
-	return &Server{
-		PortOffset:     s.allocatePort(),
+func (s *ServerManager) addServer(id string, name string, owner string, portOffset int32, startTime time.Time) *Server {
+	startContext, cancelStart := context.WithCancel(s.managerContext)
+	server := &Server{
+		Id:             id,
+		Name:           name,
+		Owner:          owner,
+		StartTime:      startTime,
+		Events:         NewEventLog(),
+		config:         s.currentConfig(),
+		PortOffset:     portOffset,
+		state:          StateStarting,
 		serverContext:  s.managerContext,
+		startContext:   startContext,
 		lastPlayerTime: time.Now(),
 		shutdown:       s.shutdownServers,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
 		freePortCb: func(port int32) {
-			s.freePort(port)
+			s.ports.free(port)
 		},
+		removeCb: func(id string) {
+			s.removeServer(id)
+		},
+		stopOnShutdownCb: func() bool {
+			return s.currentConfig().StopOnShutdown
+		},
+	}
+
+	go func() {
+		defer cancelStart()
+
+		select {
+		case <-server.stop:
+		case <-server.shutdown:
+		case <-server.done:
+		}
+	}()
+
+	s.serversMutex.Lock()
+	defer s.serversMutex.Unlock()
+
+	s.servers[server.Id] = server
+
+	return server
+}
+
+// CreateServer allocates a port for a new server and adds it to the registry of managed servers. Returns
+// ErrNoFreePorts if the worker is full.
+func (s *ServerManager) CreateServer(name string, owner string) (*Server, error) {
+	portOffset, err := s.ports.allocate(s.currentConfig().MaxServers)
+	if err != nil {
+		return nil, err
 	}
+
+	return s.addServer(newServerId(), name, owner, portOffset, time.Now()), nil
+}
+
+// AdoptServer adds a server which is already running, e.g. from before a restart of the worker, to the registry. Its
+// port offset is reserved so that no new server will use it.
+func (s *ServerManager) AdoptServer(id string, name string, owner string, portOffset int32, startTime time.Time) *Server {
+	s.ports.reserve(portOffset)
+
+	return s.addServer(id, name, owner, portOffset, startTime)
+}
+
+// FindServer returns the server with the specified ID or nil if no such server exists
+func (s *ServerManager) FindServer(id string) *Server {
+	s.serversMutex.Lock()
+	defer s.serversMutex.Unlock()
+
+	return s.servers[id]
+}
+
+// Servers returns all currently managed servers ordered by their start time
+func (s *ServerManager) Servers() []*Server {
+	s.serversMutex.Lock()
+	servers := make([]*Server, 0, len(s.servers))
+	for _, server := range s.servers {
+		servers = append(servers, server)
+	}
+	s.serversMutex.Unlock()
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].StartTime.Before(servers[j].StartTime)
+	})
+
+	return servers
 }
 
 func (s *ServerManager) Shutdown() {
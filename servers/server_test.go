@@ -0,0 +1,313 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scp-fs2open/CommnodeWorker/containers"
+	"github.com/scp-fs2open/CommnodeWorker/containers/memory"
+	"github.com/scp-fs2open/CommnodeWorker/fsoApi"
+)
+
+// How long a test waits for something the management loop should do on its own
+const testTimeout = time.Second * 5
+
+type testServer struct {
+	manager *ServerManager
+	server  *Server
+	runtime *memory.Runtime
+
+	containerId string
+
+	events chan LifecycleEvent
+}
+
+// startTestServer creates a server in a memory runtime and starts managing it. The server API is answered by the
+// handler.
+func startTestServer(t *testing.T, config Config, handler http.HandlerFunc) *testServer {
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	manager := NewServerManager(config, func(portOffset int32) bool { return true })
+	server, err := manager.CreateServer("Test Server", "Alpha 1")
+	if err != nil {
+		t.Fatalf("failed to create the server: %v", err)
+	}
+
+	runtime := memory.NewRuntime()
+	container := containers.NewServerContainer(runtime, containers.Config{
+		InstanceId:  "test",
+		Image:       "fso",
+		BaseApiPort: 8080,
+		BaseUdpPort: 7808,
+	}, containers.ServerMetadata{
+		ServerId:   server.Id,
+		Name:       server.Name,
+		Owner:      server.Owner,
+		PortOffset: uint16(server.PortOffset),
+		Created:    server.StartTime,
+	}, "user", "secret")
+	server.AttachContainer(container)
+
+	err = container.Start(context.Background(), func(event containers.ContainerEvent) error { return nil })
+	if err != nil {
+		t.Fatalf("failed to start the container: %v", err)
+	}
+
+	client := fsoApi.NewClientWithDialer(container.ApiPort, fsoApi.Credentials{Username: "user", Password: "secret"},
+		func(ctx context.Context, network, addr string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, api.Listener.Addr().String())
+		})
+	t.Cleanup(client.Close)
+
+	// Large enough that the loop never blocks on the test
+	events := make(chan LifecycleEvent, 100)
+	go server.ManageServer(container, client, func(event LifecycleEvent) {
+		events <- event
+	})
+
+	return &testServer{
+		manager:     manager,
+		server:      server,
+		runtime:     runtime,
+		containerId: container.ContainerId(),
+		events:      events,
+	}
+}
+
+// nextEvent returns the next event reported to the listener
+func (s *testServer) nextEvent(t *testing.T) LifecycleEvent {
+	t.Helper()
+
+	select {
+	case event := <-s.events:
+		return event
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for a lifecycle event")
+		return LifecycleEvent{}
+	}
+}
+
+// assertReleased checks that the stopped server is gone and that its port can be used for a new server
+func (s *testServer) assertReleased(t *testing.T) {
+	t.Helper()
+
+	if s.manager.FindServer(s.server.Id) != nil {
+		t.Error("expected the server to be removed from its manager")
+	}
+	if info := s.server.Info(); info.State != StateStopped || info.PortOffset != -1 {
+		t.Errorf("expected the server to be stopped without a port, got %v with offset %v", info.State, info.PortOffset)
+	}
+	if ids := s.runtime.Containers(); len(ids) != 0 {
+		t.Errorf("expected the container to be removed, got %v", ids)
+	}
+
+	// The port is freed in the background
+	deadline := time.Now().Add(testTimeout)
+	for {
+		server, err := s.manager.CreateServer("Next Server", "Alpha 2")
+		if err == nil {
+			if server.PortOffset != 0 {
+				t.Errorf("expected the port offset to be reused, got %v", server.PortOffset)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("port was not freed: %v", err)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func testConfig() Config {
+	return Config{
+		MaxServers: 1,
+		// Long enough to never check the players unless a test needs it
+		CheckInterval:  time.Minute,
+		IdleTimeout:    time.Minute * 5,
+		IdleWarning:    time.Minute,
+		PortQuarantine: time.Second,
+		StopOnShutdown: true,
+	}
+}
+
+func noPlayers(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("[]"))
+}
+
+func TestManageServerStops(t *testing.T) {
+	tests := []struct {
+		name       string
+		stop       func(t *testing.T, s *testServer)
+		stopReason StopReason
+		exitCode   int64
+	}{
+		{
+			name: "exit",
+			stop: func(t *testing.T, s *testServer) {
+				if err := s.runtime.Exit(s.containerId, 0); err != nil {
+					t.Fatal(err)
+				}
+			},
+			stopReason: StopReasonExited,
+			exitCode:   0,
+		},
+		{
+			name: "crash",
+			stop: func(t *testing.T, s *testServer) {
+				if err := s.runtime.Exit(s.containerId, 3); err != nil {
+					t.Fatal(err)
+				}
+			},
+			stopReason: StopReasonExited,
+			exitCode:   3,
+		},
+		{
+			name: "stop request",
+			stop: func(t *testing.T, s *testServer) {
+				ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+				defer cancel()
+
+				if err := s.server.Stop(ctx); err != nil {
+					t.Fatalf("failed to stop the server: %v", err)
+				}
+			},
+			stopReason: StopReasonRequested,
+			exitCode:   0,
+		},
+		{
+			name: "shutdown",
+			stop: func(t *testing.T, s *testServer) {
+				s.manager.Shutdown()
+			},
+			stopReason: StopReasonShutdown,
+			exitCode:   0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := startTestServer(t, testConfig(), noPlayers)
+
+			test.stop(t, s)
+
+			event := s.nextEvent(t)
+			if event.Type != LifecycleStopped {
+				t.Fatalf("expected a stopped event, got %+v", event)
+			}
+			if event.StopReason != test.stopReason || event.ExitCode != test.exitCode {
+				t.Errorf("expected stop reason %v with exit code %v, got %v with %v", test.stopReason, test.exitCode, event.StopReason, event.ExitCode)
+			}
+
+			if test.stopReason != StopReasonShutdown {
+				// A shut down manager does not hand out ports anymore
+				s.assertReleased(t)
+			}
+		})
+	}
+}
+
+func TestManageServerLeaveRunning(t *testing.T) {
+	config := testConfig()
+	config.StopOnShutdown = false
+	s := startTestServer(t, config, noPlayers)
+
+	s.manager.Shutdown()
+
+	select {
+	case <-s.server.done:
+	case <-time.After(testTimeout):
+		t.Fatal("the management loop did not finish")
+	}
+
+	select {
+	case event := <-s.events:
+		t.Errorf("expected no event for a server which keeps running, got %+v", event)
+	default:
+	}
+	if ids := s.runtime.Containers(); len(ids) != 1 {
+		t.Errorf("expected the container to keep running, got %v", ids)
+	}
+}
+
+func TestManageServerStopOnShutdownReloaded(t *testing.T) {
+	s := startTestServer(t, testConfig(), noPlayers)
+
+	// Running servers follow a reload of this setting
+	config := testConfig()
+	config.StopOnShutdown = false
+	s.manager.SetConfig(config)
+
+	s.manager.Shutdown()
+
+	select {
+	case <-s.server.done:
+	case <-time.After(testTimeout):
+		t.Fatal("the management loop did not finish")
+	}
+
+	if ids := s.runtime.Containers(); len(ids) != 1 {
+		t.Errorf("expected the container to keep running, got %v", ids)
+	}
+}
+
+func TestManageServerIdleTimeout(t *testing.T) {
+	config := testConfig()
+	config.CheckInterval = time.Millisecond * 20
+	config.IdleTimeout = time.Millisecond * 200
+	config.IdleWarning = time.Millisecond * 100
+
+	var mutex sync.Mutex
+	requests := 0
+	s := startTestServer(t, config, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/1/player" {
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+		}
+
+		mutex.Lock()
+		requests++
+		request := requests
+		mutex.Unlock()
+
+		// A player is there for the first two checks and leaves again
+		var players []fsoApi.PlayerData
+		if request <= 2 {
+			players = append(players, fsoApi.PlayerData{Id: 1, Callsign: "Alpha 1"})
+		}
+		json.NewEncoder(w).Encode(players)
+	})
+
+	joined := s.nextEvent(t)
+	if joined.Type != LifecyclePlayerJoined || joined.Player.Callsign != "Alpha 1" {
+		t.Errorf("expected the player to join, got %+v", joined)
+	}
+	left := s.nextEvent(t)
+	if left.Type != LifecyclePlayerLeft || left.Player.Id != 1 {
+		t.Errorf("expected the player to leave, got %+v", left)
+	}
+
+	warning := s.nextEvent(t)
+	if warning.Type != LifecycleIdleWarning {
+		t.Fatalf("expected an idle warning, got %+v", warning)
+	}
+	if warning.IdleTimeLeft <= 0 || warning.IdleTimeLeft > config.IdleWarning {
+		t.Errorf("expected the time left to be within the warning period, got %v", warning.IdleTimeLeft)
+	}
+
+	stopped := s.nextEvent(t)
+	if stopped.Type != LifecycleStopped {
+		t.Fatalf("expected a stopped event, got %+v", stopped)
+	}
+	if stopped.StopReason != StopReasonIdle || stopped.ExitCode != 0 {
+		t.Errorf("expected an idle stop with exit code 0, got %v with %v", stopped.StopReason, stopped.ExitCode)
+	}
+
+	s.assertReleased(t)
+}